@@ -0,0 +1,152 @@
+package deepcopy
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type FirmwareSensor struct {
+	Readings [4]int32
+	Teachers [2]*LocalTeacher
+}
+
+type ApiSensor struct {
+	Readings []int64
+	Teachers []PbTeacher
+}
+
+type WideSensor struct {
+	Readings [4]int64
+	Teachers [2]*LocalTeacher
+}
+
+type ShortSensor struct {
+	Readings [2]int64
+}
+
+type LongSensor struct {
+	Readings [6]int64
+}
+
+func TestArrays(t *testing.T) {
+	testCases := []struct {
+		name            string
+		copier          *Copier
+		input           interface{}
+		outputPtr       interface{}
+		expectedRespPtr interface{}
+		expectedErr     error
+	}{
+		{
+			name:   "array elements are converted",
+			copier: New(),
+			input: FirmwareSensor{
+				Readings: [4]int32{1, 2, 3, 4},
+			},
+			outputPtr: &WideSensor{},
+			expectedRespPtr: &WideSensor{
+				Readings: [4]int64{1, 2, 3, 4},
+			},
+		},
+		{
+			name:   "array to slice",
+			copier: New(),
+			input: FirmwareSensor{
+				Readings: [4]int32{1, 2, 3, 4},
+				Teachers: [2]*LocalTeacher{{TeacherID: 1}, nil},
+			},
+			outputPtr: &ApiSensor{},
+			expectedRespPtr: &ApiSensor{
+				Readings: []int64{1, 2, 3, 4},
+				Teachers: []PbTeacher{{TeacherId: 1}, {}},
+			},
+		},
+		{
+			name:   "slice to array",
+			copier: New(),
+			input: ApiSensor{
+				Readings: []int64{1, 2, 3, 4},
+			},
+			outputPtr: &FirmwareSensor{},
+			expectedRespPtr: &FirmwareSensor{
+				Readings: [4]int32{1, 2, 3, 4},
+			},
+		},
+		{
+			name:   "longer slice to array fails by default",
+			copier: New(),
+			input: ApiSensor{
+				Readings: []int64{1, 2, 3, 4, 5},
+			},
+			outputPtr:   &FirmwareSensor{},
+			expectedErr: errors.New("Readings: unable to convert [%!s(int64=1) %!s(int64=2) %!s(int64=3) %!s(int64=4) %!s(int64=5)] (type []int64) to type [4]int32: length 5 does not match length 4"),
+		},
+		{
+			name:   "shorter array to array fails by default",
+			copier: New(),
+			input: ShortSensor{
+				Readings: [2]int64{1, 2},
+			},
+			outputPtr:   &FirmwareSensor{},
+			expectedErr: errors.New("Readings: unable to convert [%!s(int64=1) %!s(int64=2)] (type [2]int64) to type [4]int32: length 2 does not match length 4"),
+		},
+		{
+			name:   "longer array to array is truncated",
+			copier: New(WithLengthPolicy(LengthTruncate)),
+			input: LongSensor{
+				Readings: [6]int64{1, 2, 3, 4, 5, 6},
+			},
+			outputPtr: &FirmwareSensor{},
+			expectedRespPtr: &FirmwareSensor{
+				Readings: [4]int32{1, 2, 3, 4},
+			},
+		},
+		{
+			name:   "shorter slice to array is zero padded",
+			copier: New(WithLengthPolicy(LengthZeroPad)),
+			input: ApiSensor{
+				Readings: []int64{1, 2},
+			},
+			outputPtr: &FirmwareSensor{
+				Readings: [4]int32{9, 9, 9, 9},
+			},
+			expectedRespPtr: &FirmwareSensor{
+				Readings: [4]int32{1, 2, 0, 0},
+			},
+		},
+		{
+			name:   "truncate does not allow padding",
+			copier: New(WithLengthPolicy(LengthTruncate)),
+			input: ShortSensor{
+				Readings: [2]int64{1, 2},
+			},
+			outputPtr:   &FirmwareSensor{},
+			expectedErr: errors.New("Readings: unable to convert [%!s(int64=1) %!s(int64=2)] (type [2]int64) to type [4]int32: length 2 does not match length 4"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.copier.Copy(tc.input, tc.outputPtr)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedRespPtr, tc.outputPtr)
+			}
+		})
+	}
+}
+
+func TestArraysAreNotShared(t *testing.T) {
+	input := FirmwareSensor{
+		Teachers: [2]*LocalTeacher{{TeacherID: 1}, {TeacherID: 2}},
+	}
+	out := FirmwareSensor{}
+	err := DeepCopy(input, &out)
+	require.NoError(t, err)
+	assert.Equal(t, input, out)
+	assert.NotSame(t, input.Teachers[0], out.Teachers[0])
+}
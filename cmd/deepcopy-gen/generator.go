@@ -0,0 +1,1328 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/fluidtruck/deepcopy"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	deepcopyPath    = "github.com/fluidtruck/deepcopy"
+	timestamppbPath = "google.golang.org/protobuf/types/known/timestamppb"
+	durationpbPath  = "google.golang.org/protobuf/types/known/durationpb"
+)
+
+// typePair is a -type flag: the source and destination type references
+// and the optional name of the generated function.
+type typePair struct {
+	src      string
+	dst      string
+	funcName string
+}
+
+func (p typePair) String() string {
+	if p.funcName == "" {
+		return p.src + ":" + p.dst
+	}
+	return p.src + ":" + p.dst + ":" + p.funcName
+}
+
+// convFunc is a generated conversion between two named struct types.
+type convFunc struct {
+	name string
+	src  *types.Named
+	dst  *types.Named
+}
+
+type namedPair struct {
+	src *types.Named
+	dst *types.Named
+}
+
+// config holds the options the generated code follows.
+type config struct {
+	// tagName, fallbackTags, caseSensitive and naming mirror the options
+	// fields are matched with.
+	tagName       string
+	fallbackTags  []string
+	caseSensitive bool
+	naming        []namingOption
+	// floatFormat, floatPrecision, trueString and falseString mirror
+	// deepcopy.WithFloatFormat and deepcopy.WithBoolStrings.
+	floatFormat    byte
+	floatPrecision int
+	trueString     string
+	falseString    string
+	// overflow and parseFormats mirror deepcopy.WithOverflowPolicy and
+	// deepcopy.WithParseFormats.
+	overflow     deepcopy.OverflowPolicy
+	parseFormats deepcopy.ParseFormat
+}
+
+// namingOption is a deepcopy naming strategy, and its name: a variable such
+// as SnakeCase, or StripPrefix or StripSuffix called with args.
+type namingOption struct {
+	strategy deepcopy.NamingStrategy
+	name     string
+	args     []string
+}
+
+type generator struct {
+	config
+	copier   *deepcopy.Copier
+	fset     *token.FileSet
+	importer types.ImporterFrom
+	pkg      *types.Package
+
+	// imports maps import paths used by the generated code to their names.
+	imports map[string]string
+	funcs   map[namedPair]*convFunc
+	queue   []*convFunc
+	// copiesInterfaces is set when values held in interfaces are copied at
+	// run time, and prefixesErrors when errors of nested values are returned,
+	// which need the helpers written by writeErrorAt.
+	copiesInterfaces bool
+	prefixesErrors   bool
+}
+
+// generate type-checks the package in dir, ignoring the file named output,
+// and returns the source of a file holding conversions for pairs.
+func generate(dir, output string, pairs []typePair, cfg config) ([]byte, error) {
+	g := &generator{
+		config:  cfg,
+		fset:    token.NewFileSet(),
+		imports: make(map[string]string),
+		funcs:   make(map[namedPair]*convFunc),
+	}
+	var opts []deepcopy.Option
+	for _, opt := range g.copierOptions() {
+		opts = append(opts, opt.option)
+	}
+	g.copier = deepcopy.New(opts...)
+	g.importer = importer.ForCompiler(g.fset, "source", nil).(types.ImporterFrom)
+	if err := g.loadPackage(dir, output); err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	for _, pair := range pairs {
+		src, err := g.lookupType(dir, pair.src)
+		if err != nil {
+			return nil, err
+		}
+		dst, err := g.lookupType(dir, pair.dst)
+		if err != nil {
+			return nil, err
+		}
+		f := g.convFunc(src, dst)
+		name := pair.funcName
+		if name == "" {
+			name = "Convert" + g.typeIdent(src) + "To" + g.typeIdent(dst)
+		}
+		fmt.Fprintf(&body, "// %s copies in into out following the same rules as\n", name)
+		fmt.Fprintf(&body, "// deepcopy.DeepCopy, without using reflection.\n")
+		fmt.Fprintf(&body, "func %s(in *%s, out *%s) error {\n", name, g.typeString(f.src), g.typeString(f.dst))
+		fmt.Fprintf(&body, "return %s(in, out)\n}\n\n", f.name)
+	}
+	for i := 0; i < len(g.queue); i++ {
+		if err := g.writeConvFunc(&body, g.queue[i]); err != nil {
+			return nil, err
+		}
+	}
+	if g.prefixesErrors {
+		g.writeErrorAt(&body)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by deepcopy-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", g.pkg.Name())
+	// some expressions are built for code that is never written, so only
+	// packages referred to outside of comments are imported
+	code := regexp.MustCompile(`(?m)^//.*$`).ReplaceAll(body.Bytes(), nil)
+	paths := make([]string, 0, len(g.imports))
+	for path, name := range g.imports {
+		if regexp.MustCompile(`(^|[^.\w])` + name + `\.`).Match(code) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	fmt.Fprintf(&buf, "import (\n")
+	for _, path := range paths {
+		if name := g.imports[path]; name != filepath.Base(path) {
+			fmt.Fprintf(&buf, "%s %q\n", name, path)
+		} else {
+			fmt.Fprintf(&buf, "%q\n", path)
+		}
+	}
+	fmt.Fprintf(&buf, ")\n\n")
+	buf.Write(body.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+// writeErrorAt writes the function adding the location of nested values to
+// their errors, and the Copier that values held in interfaces are copied
+// with, unless DeepCopy is used.
+func (g *generator) writeErrorAt(w *bytes.Buffer) {
+	pkg := g.use(deepcopyPath)
+	if opts := g.copierOptions(); g.copiesInterfaces && len(opts) > 0 {
+		exprs := make([]string, len(opts))
+		for i, opt := range opts {
+			exprs[i] = opt.expr
+		}
+		fmt.Fprintf(w, "// deepcopyCopier copies values held in interfaces with the options\n")
+		fmt.Fprintf(w, "// deepcopy-gen was run with.\n")
+		fmt.Fprintf(w, "var deepcopyCopier = %s.New(\n%s,\n)\n\n", pkg, strings.Join(exprs, ",\n"))
+	}
+	fmt.Fprintf(w, "// deepcopyErrorAt adds path, the location of a nested value, to the\n")
+	fmt.Fprintf(w, "// path of an error returned when copying it. Paths are only built\n")
+	fmt.Fprintf(w, "// when a copy fails.\n")
+	fmt.Fprintf(w, "func deepcopyErrorAt(path string, err error) error {\n")
+	fmt.Fprintf(w, "var errPath *string\nswitch err := err.(type) {\n")
+	fmt.Fprintf(w, "case *%s.ConversionError:\nerrPath = &err.Path\n", pkg)
+	fmt.Fprintf(w, "case *%s.RequiredFieldError:\nerrPath = &err.Path\n", pkg)
+	fmt.Fprintf(w, "default:\nreturn err\n}\n")
+	fmt.Fprintf(w, "if *errPath != \"\" && !%s.HasPrefix(*errPath, \"[\") {\npath += \".\"\n}\n", g.use("strings"))
+	fmt.Fprintf(w, "*errPath = path + *errPath\nreturn err\n}\n")
+}
+
+// copierOption is a deepcopy option and the Go expression creating it in
+// the generated code.
+type copierOption struct {
+	option deepcopy.Option
+	expr   string
+}
+
+// copierOptions returns the deepcopy options the config mirrors, leaving
+// out those at their defaults. Fields are matched with a Copier created with
+// them, and values held in interfaces are copied with one at run time.
+func (g *generator) copierOptions() []copierOption {
+	pkg := g.use(deepcopyPath)
+	var opts []copierOption
+	add := func(option deepcopy.Option, format string, args ...interface{}) {
+		opts = append(opts, copierOption{option: option, expr: pkg + "." + fmt.Sprintf(format, args...)})
+	}
+	if g.tagName != "" && g.tagName != deepcopy.DC_STRUCT_TAG {
+		add(deepcopy.WithTagName(g.tagName), "WithTagName(%q)", g.tagName)
+	}
+	if len(g.fallbackTags) > 0 {
+		add(deepcopy.WithFallbackTags(g.fallbackTags...), "WithFallbackTags(%s)", quoteAll(g.fallbackTags))
+	}
+	if g.caseSensitive {
+		add(deepcopy.WithCaseSensitiveNames(), "WithCaseSensitiveNames()")
+	}
+	if len(g.naming) > 0 {
+		strategies := make([]deepcopy.NamingStrategy, len(g.naming))
+		exprs := make([]string, len(g.naming))
+		for i, n := range g.naming {
+			strategies[i] = n.strategy
+			exprs[i] = pkg + "." + n.name
+			if n.args != nil {
+				exprs[i] += "(" + quoteAll(n.args) + ")"
+			}
+		}
+		add(deepcopy.WithNamingStrategy(strategies...), "WithNamingStrategy(%s)", strings.Join(exprs, ", "))
+	}
+	if g.floatFormat != 'f' || g.floatPrecision != -1 {
+		add(deepcopy.WithFloatFormat(g.floatFormat, g.floatPrecision), "WithFloatFormat(%q, %d)", g.floatFormat, g.floatPrecision)
+	}
+	if g.trueString != "true" || g.falseString != "false" {
+		add(deepcopy.WithBoolStrings(g.trueString, g.falseString), "WithBoolStrings(%q, %q)", g.trueString, g.falseString)
+	}
+	if g.overflow != deepcopy.OverflowError {
+		add(deepcopy.WithOverflowPolicy(g.overflow), "WithOverflowPolicy(%s.%s)", pkg, overflowPolicyNames[g.overflow])
+	}
+	if g.parseFormats != deepcopy.ParseDefault {
+		formats := strings.TrimSuffix(strings.TrimPrefix(g.parseFormatsExpr(), "("), ")")
+		add(deepcopy.WithParseFormats(g.parseFormats), "WithParseFormats(%s)", formats)
+	}
+	return opts
+}
+
+var overflowPolicyNames = map[deepcopy.OverflowPolicy]string{
+	deepcopy.OverflowError:    "OverflowError",
+	deepcopy.OverflowTruncate: "OverflowTruncate",
+	deepcopy.OverflowSaturate: "OverflowSaturate",
+	deepcopy.OverflowAllow:    "OverflowAllow",
+}
+
+// quoteAll returns values as comma-separated Go string literals.
+func quoteAll(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// loadPackage parses and type-checks the non-test Go files in dir.
+// Type errors are ignored, since the package may call functions that are
+// only declared in the file being generated.
+func (g *generator) loadPackage(dir, output string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || name == output || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(g.fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return err
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no Go files in %s", dir)
+	}
+	conf := types.Config{
+		Importer: g.importer,
+		Error:    func(error) {},
+	}
+	g.pkg, _ = conf.Check(files[0].Name.Name, g.fset, files, nil)
+	return nil
+}
+
+// lookupType resolves a type reference, either a name declared in the
+// current package or an import path followed by a dot and a name.
+func (g *generator) lookupType(dir, ref string) (*types.Named, error) {
+	pkg := g.pkg
+	name := ref
+	if i := strings.LastIndex(ref, "."); i >= 0 {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		pkg, err = g.importer.ImportFrom(ref[:i], absDir, 0)
+		if err != nil {
+			return nil, err
+		}
+		name = ref[i+1:]
+	}
+	obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type %s not found", ref)
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a named type", ref)
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil, fmt.Errorf("%s is not a struct type", ref)
+	}
+	return named, nil
+}
+
+// convFunc returns the conversion function for src and dst, queueing it
+// for generation on first use.
+func (g *generator) convFunc(src, dst *types.Named) *convFunc {
+	pair := namedPair{src: src, dst: dst}
+	if f, ok := g.funcs[pair]; ok {
+		return f
+	}
+	f := &convFunc{
+		name: "convert" + g.typeIdent(src) + "To" + g.typeIdent(dst),
+		src:  src,
+		dst:  dst,
+	}
+	g.funcs[pair] = f
+	g.queue = append(g.queue, f)
+	return f
+}
+
+func (g *generator) writeConvFunc(w *bytes.Buffer, f *convFunc) error {
+	fw := &funcWriter{}
+	fw.printf("func %s(in *%s, out *%s) error {\n", f.name, g.typeString(f.src), g.typeString(f.dst))
+	hooks, err := g.hooks(f.dst)
+	if err != nil {
+		return err
+	}
+	hookErr := g.conversionError(`""`, "*in", "*out", "err")
+	if hooks["DeepCopierFrom"] {
+		fw.printf("if handled, err := out.DeepCopyFrom(*in); err != nil {\nreturn %s\n} else if handled {\nreturn nil\n}\n", hookErr)
+	}
+	if hooks["BeforeDeepCopier"] {
+		fw.printf("if err := out.BeforeDeepCopy(*in); err != nil {\nreturn %s\n}\n", hookErr)
+	}
+	srcStruct := f.src.Underlying().(*types.Struct)
+	dstStruct := f.dst.Underlying().(*types.Struct)
+	for _, m := range g.fieldMappings(srcStruct, dstStruct) {
+		// fields promoted from embedded pointers need nil checks in the
+		// source and allocations in the destination
+		src, guards := "in", []string(nil)
+		for i, v := range m.src {
+			src += "." + v.Name()
+			if _, ok := v.Type().Underlying().(*types.Pointer); ok && i < len(m.src)-1 {
+				guards = append(guards, src+" != nil")
+			}
+		}
+		dst, allocs := "out", ""
+		for i, v := range m.dst {
+			dst += "." + v.Name()
+			if ptr, ok := v.Type().Underlying().(*types.Pointer); ok && i < len(m.dst)-1 {
+				allocs += fmt.Sprintf("if %s == nil {\n%s = new(%s)\n}\n", dst, dst, g.typeString(ptr.Elem()))
+			}
+		}
+		srcField := m.src[len(m.src)-1]
+		dstField := m.dst[len(m.dst)-1]
+		path := strconv.Quote(m.name)
+
+		if m.copyZero {
+			fw.printf("%s", allocs)
+			if guards != nil {
+				fw.printf("if %s {\n", strings.Join(guards, " && "))
+			}
+		} else {
+			fw.printf("if %s {\n", strings.Join(append(guards, g.nonZero(src, srcField.Type())), " && "))
+			fw.printf("%s", allocs)
+			fw.nonNil = src
+		}
+		err := g.assign(fw, dst, dstField.Type(), src, srcField.Type(), path, true)
+		fw.nonNil = ""
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", types.TypeString(f.src, nil), m.name, err)
+		}
+		if m.copyZero && guards != nil {
+			fw.printf("} else {\n%s = %s\n", dst, g.zero(dstField.Type()))
+		}
+		if !m.copyZero || guards != nil {
+			fw.printf("}\n")
+		}
+	}
+	for _, vars := range g.requiredFields(dstStruct) {
+		dst, conds := "out", []string(nil)
+		for i, v := range vars {
+			dst += "." + v.Name()
+			if _, ok := v.Type().Underlying().(*types.Pointer); ok && i < len(vars)-1 {
+				conds = append(conds, dst+" == nil")
+			}
+		}
+		field := vars[len(vars)-1]
+		conds = append(conds, g.isZero(dst, field.Type()))
+		fw.printf("if %s {\n", strings.Join(conds, " || "))
+		fw.printf("return &%s.RequiredFieldError{Path: %q, Type: %s.TypeOf((*%s)(nil)).Elem()}\n}\n",
+			g.use(deepcopyPath), field.Name(), g.use("reflect"), g.typeString(field.Type()))
+	}
+	if hooks["AfterDeepCopier"] {
+		fw.printf("if err := out.AfterDeepCopy(*in); err != nil {\nreturn %s\n}\n", hookErr)
+	}
+	fw.printf("return nil\n}\n\n")
+	w.Write(fw.buf.Bytes())
+	return nil
+}
+
+// fieldMapping is a deepcopy.FieldMapping translated to the chains of
+// struct fields selected by its index sequences.
+type fieldMapping struct {
+	name     string
+	src      []*types.Var
+	dst      []*types.Var
+	copyZero bool
+}
+
+// fieldMappings asks the Copier which fields it would copy, using reflect
+// stand-ins for the two structs, and translates the result back to fields
+// of src and dst.
+func (g *generator) fieldMappings(src, dst *types.Struct) []fieldMapping {
+	srcShadow := newShadow(src, nil)
+	dstShadow := newShadow(dst, nil)
+	var mappings []fieldMapping
+	for _, m := range g.copier.FieldMappings(srcShadow.typ, dstShadow.typ) {
+		mappings = append(mappings, fieldMapping{
+			name:     m.Name,
+			src:      srcShadow.fields(m.Src),
+			dst:      dstShadow.fields(m.Dst),
+			copyZero: m.CopyZero,
+		})
+	}
+	return mappings
+}
+
+// copyMethod returns the name of the method t provides to copy its own
+// values, preferring DeepCopyInto(out *T) over DeepCopy() *T over
+// Clone() T, or "" when it has none.
+func (g *generator) copyMethod(t types.Type) string {
+	if _, ok := t.(*types.Named); !ok {
+		return ""
+	}
+	ptr := types.NewPointer(t)
+	for _, name := range []string{"DeepCopyInto", "DeepCopy", "Clone"} {
+		obj, _, _ := types.LookupFieldOrMethod(ptr, false, nil, name)
+		fn, ok := obj.(*types.Func)
+		if !ok {
+			continue
+		}
+		sig := fn.Type().(*types.Signature)
+		params, results := sig.Params(), sig.Results()
+		switch name {
+		case "DeepCopyInto":
+			if params.Len() == 1 && types.Identical(params.At(0).Type(), ptr) && results.Len() == 0 {
+				return name
+			}
+		case "DeepCopy":
+			if params.Len() == 0 && results.Len() == 1 && types.Identical(results.At(0).Type(), ptr) {
+				return name
+			}
+		case "Clone":
+			if params.Len() == 0 && results.Len() == 1 && types.Identical(results.At(0).Type(), t) {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// durationType returns the time.Duration type.
+func (g *generator) durationType() (types.Type, error) {
+	pkg, err := g.importer.Import("time")
+	if err != nil {
+		return nil, err
+	}
+	return pkg.Scope().Lookup("Duration").Type(), nil
+}
+
+// hooks returns which of the deepcopy hook interfaces, by name, pointers
+// to dst implement.
+func (g *generator) hooks(dst *types.Named) (map[string]bool, error) {
+	pkg, err := g.importer.Import(deepcopyPath)
+	if err != nil {
+		return nil, err
+	}
+	hooks := make(map[string]bool)
+	for _, name := range []string{"DeepCopierFrom", "BeforeDeepCopier", "AfterDeepCopier"} {
+		iface := pkg.Scope().Lookup(name).Type().Underlying().(*types.Interface)
+		hooks[name] = types.Implements(types.NewPointer(dst), iface)
+	}
+	return hooks, nil
+}
+
+// requiredFields returns the chains of fields of dst tagged required.
+func (g *generator) requiredFields(dst *types.Struct) [][]*types.Var {
+	dstShadow := newShadow(dst, nil)
+	var required [][]*types.Var
+	for _, index := range g.copier.RequiredFields(dstShadow.typ) {
+		required = append(required, dstShadow.fields(index))
+	}
+	return required
+}
+
+var (
+	shadowValueType   = reflect.TypeOf(0)
+	shadowPointerType = reflect.TypeOf((*int)(nil))
+)
+
+// shadow is a reflect struct type with the same exported field names, tags,
+// pointer-ness and nested structs as a types.Struct. Field matching does
+// not depend on anything else.
+type shadow struct {
+	typ reflect.Type
+	// vars holds the field of the types.Struct for each shadow field, and
+	// nested the shadows of struct-typed fields.
+	vars   []*types.Var
+	nested map[int]*shadow
+}
+
+// newShadow returns the shadow of st. Structs already in seen are not
+// expanded again, so that recursive types terminate.
+func newShadow(st *types.Struct, seen []*types.Struct) *shadow {
+	sh := &shadow{nested: make(map[int]*shadow)}
+	seen = append(seen, st)
+	var fields []reflect.StructField
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if !field.Exported() {
+			continue
+		}
+		shadowType := shadowValueType
+		ptr, isPtr := field.Type().Underlying().(*types.Pointer)
+		if isPtr {
+			shadowType = shadowPointerType
+		}
+		t := field.Type()
+		if isPtr {
+			t = ptr.Elem()
+		}
+		if nst, ok := t.Underlying().(*types.Struct); ok && !containsStruct(seen, nst) {
+			nsh := newShadow(nst, seen)
+			sh.nested[len(fields)] = nsh
+			shadowType = nsh.typ
+			if isPtr {
+				shadowType = reflect.PtrTo(shadowType)
+			}
+		}
+		fields = append(fields, reflect.StructField{
+			Name:      field.Name(),
+			Type:      shadowType,
+			Tag:       reflect.StructTag(st.Tag(i)),
+			Anonymous: field.Embedded() && sh.nested[len(fields)] != nil,
+		})
+		sh.vars = append(sh.vars, field)
+	}
+	sh.typ = reflect.StructOf(fields)
+	return sh
+}
+
+// fields returns the chain of fields selected by a shadow index sequence.
+func (sh *shadow) fields(index []int) []*types.Var {
+	vars := make([]*types.Var, len(index))
+	for i, x := range index {
+		vars[i] = sh.vars[x]
+		sh = sh.nested[x]
+	}
+	return vars
+}
+
+func containsStruct(structs []*types.Struct, st *types.Struct) bool {
+	for _, other := range structs {
+		if types.Identical(other, st) {
+			return true
+		}
+	}
+	return false
+}
+
+// assign writes statements that copy the expression src of type srcT into
+// the addressable expression dst of type dstT, mirroring smartCopy. path is
+// an expression, ending with a string literal, evaluating to the error path
+// of src within the struct being converted; it is only evaluated when an
+// error is returned. clearNil sets dst to
+// its zero value when src is a nil pointer, as DeepCopy does for fields.
+func (g *generator) assign(fw *funcWriter, dst string, dstT types.Type, src string, srcT types.Type, path string, clearNil bool) error {
+	// values held in interfaces can only be copied at run time
+	if isInterface(dstT) || isInterface(srcT) {
+		g.copiesInterfaces, g.prefixesErrors = true, true
+		copyFunc := g.use(deepcopyPath) + ".DeepCopy"
+		if len(g.copierOptions()) > 0 {
+			copyFunc = "deepcopyCopier.Copy"
+		}
+		fw.printf("if err := %s(%s, &%s); err != nil {\nreturn deepcopyErrorAt(%s, err)\n}\n", copyFunc, src, dst, path)
+		return nil
+	}
+
+	// *timestamppb.Timestamp values are converted without dereferencing
+	if isNamed(srcT, timestamppbPath, "Timestamp", true) {
+		switch {
+		case isNamed(dstT, timestamppbPath, "Timestamp", true):
+			fw.printf("%s = %s\n", dst, src)
+		case isNamed(dstT, "time", "Time", false):
+			fw.printf("%s = %s.AsTime()\n", dst, src)
+		case isNamed(dstT, "time", "Time", true):
+			t := fw.tmp("t")
+			fw.printf("%s := %s.AsTime()\n%s = &%s\n", t, src, dst, t)
+		default:
+			return errUnconvertible(srcT, dstT)
+		}
+		return nil
+	}
+	if isNamed(dstT, timestamppbPath, "Timestamp", true) {
+		if ptr, ok := srcT.Underlying().(*types.Pointer); ok {
+			return g.assignFromPointer(fw, dst, dstT, src, ptr, path, clearNil)
+		}
+		if !isNamed(srcT, "time", "Time", false) {
+			return errUnconvertible(srcT, dstT)
+		}
+		fw.printf("%s = %s.New(%s)\n", dst, g.use(timestamppbPath), src)
+		return nil
+	}
+
+	// *durationpb.Duration values are converted like time.Duration
+	srcDurationpb := isNamed(srcT, durationpbPath, "Duration", true)
+	dstDurationpb := isNamed(dstT, durationpbPath, "Duration", true)
+	if ptr, ok := srcT.Underlying().(*types.Pointer); ok && (!srcDurationpb || dstDurationpb) {
+		return g.assignFromPointer(fw, dst, dstT, src, ptr, path, clearNil)
+	}
+	if dstDurationpb && isBasic(srcT) {
+		durationT, err := g.durationType()
+		if err != nil {
+			return err
+		}
+		d := fw.tmp("d")
+		fw.printf("var %s %s\n", d, g.typeString(durationT))
+		if err := g.assign(fw, d, durationT, src, srcT, path, false); err != nil {
+			return err
+		}
+		fw.printf("%s = %s.New(%s)\n", dst, g.use(durationpbPath), d)
+		return nil
+	}
+	if ptr, ok := dstT.Underlying().(*types.Pointer); ok {
+		v := fw.tmp("v")
+		fw.printf("%s := new(%s)\n", v, g.typeString(ptr.Elem()))
+		if err := g.assign(fw, "(*"+v+")", ptr.Elem(), src, srcT, path, false); err != nil {
+			return err
+		}
+		fw.printf("%s = %s\n", dst, v)
+		return nil
+	}
+	if srcDurationpb {
+		durationT, err := g.durationType()
+		if err != nil {
+			return err
+		}
+		if src != fw.nonNil {
+			fw.printf("if %s != nil {\n", src)
+		}
+		d := fw.tmp("d")
+		fw.printf("if err := %s.CheckValid(); err != nil {\nreturn %s\n}\n", src, g.conversionError(path, src, dst, "err"))
+		fw.printf("%s := %s.AsDuration()\n", d, src)
+		if err := g.assign(fw, dst, dstT, d, durationT, path, false); err != nil {
+			return err
+		}
+		if src != fw.nonNil {
+			if clearNil {
+				fw.printf("} else {\n%s = %s\n", dst, g.zero(dstT))
+			}
+			fw.printf("}\n")
+		}
+		return nil
+	}
+
+	if basic, ok := srcT.Underlying().(*types.Basic); ok && basic.Info()&types.IsString != 0 {
+		if attempted := g.parseString(fw, dst, dstT, src, srcT, path); attempted {
+			return nil
+		}
+	}
+
+	if types.Identical(srcT, dstT) {
+		// types that copy themselves
+		switch g.copyMethod(dstT) {
+		case "DeepCopyInto":
+			fw.printf("%s.DeepCopyInto(%s)\n", src, addr(dst))
+			return nil
+		case "DeepCopy":
+			v := fw.tmp("v")
+			fw.printf("if %s := %s.DeepCopy(); %s != nil {\n%s = *%s\n} else {\n%s = %s\n}\n", v, src, v, dst, v, dst, g.zero(dstT))
+			return nil
+		case "Clone":
+			fw.printf("%s = %s.Clone()\n", dst, src)
+			return nil
+		}
+	}
+
+	switch dstU := dstT.Underlying().(type) {
+	case *types.Struct:
+		if isNamed(dstT, "time", "Time", false) {
+			if !isNamed(srcT, "time", "Time", false) {
+				return errUnconvertible(srcT, dstT)
+			}
+			fw.printf("%s = %s\n", dst, unparen(src))
+			return nil
+		}
+		srcNamed, srcOk := srcT.(*types.Named)
+		dstNamed, dstOk := dstT.(*types.Named)
+		if _, ok := srcT.Underlying().(*types.Struct); !ok || !srcOk || !dstOk {
+			return errUnconvertible(srcT, dstT)
+		}
+		f := g.convFunc(srcNamed, dstNamed)
+		g.prefixesErrors = true
+		fw.printf("if err := %s(%s, %s); err != nil {\nreturn deepcopyErrorAt(%s, err)\n}\n", f.name, addr(src), addr(dst), path)
+	case *types.Slice:
+		srcElem, ok := elemType(srcT)
+		if !ok {
+			return errUnconvertible(srcT, dstT)
+		}
+		v, i := fw.tmp("v"), fw.tmp("i")
+		fw.printf("%s := make(%s, len(%s))\n", v, g.typeString(dstT), src)
+		fw.printf("for %s := range %s {\n", i, src)
+		elemPath := elemPath(path, g.use("strconv")+".Itoa("+i+")")
+		if err := g.assign(fw, v+"["+i+"]", dstU.Elem(), src+"["+i+"]", srcElem, elemPath, false); err != nil {
+			return err
+		}
+		fw.printf("}\n%s = %s\n", dst, v)
+	case *types.Array:
+		srcElem, ok := elemType(srcT)
+		if !ok {
+			return errUnconvertible(srcT, dstT)
+		}
+		if srcArray, ok := srcT.Underlying().(*types.Array); ok && srcArray.Len() != dstU.Len() {
+			return fmt.Errorf("length %d does not match length %d", srcArray.Len(), dstU.Len())
+		}
+		if _, ok := srcT.Underlying().(*types.Slice); ok {
+			fw.printf("if len(%s) != %d {\n", src, dstU.Len())
+			cause := fmt.Sprintf("%s.Errorf(\"length %%d does not match length %d\", len(%s))", g.use("fmt"), dstU.Len(), src)
+			fw.printf("return %s\n}\n", g.conversionError(path, src, dst, cause))
+		}
+		v, i := fw.tmp("v"), fw.tmp("i")
+		fw.printf("var %s %s\n", v, g.typeString(dstT))
+		fw.printf("for %s := range %s {\n", i, v)
+		elemPath := elemPath(path, g.use("strconv")+".Itoa("+i+")")
+		if err := g.assign(fw, v+"["+i+"]", dstU.Elem(), src+"["+i+"]", srcElem, elemPath, false); err != nil {
+			return err
+		}
+		fw.printf("}\n%s = %s\n", dst, v)
+	case *types.Map:
+		srcMap, ok := srcT.Underlying().(*types.Map)
+		if !ok {
+			return errUnconvertible(srcT, dstT)
+		}
+		v, k, e := fw.tmp("v"), fw.tmp("k"), fw.tmp("e")
+		outKey, outElem := fw.tmp("k"), fw.tmp("e")
+		if src != fw.nonNil {
+			fw.printf("if %s == nil {\n%s = nil\n} else {\n", src, dst)
+		}
+		fw.printf("%s := make(%s, len(%s))\n", v, g.typeString(dstT), src)
+		fw.printf("for %s, %s := range %s {\n", k, e, src)
+		elemPath := elemPath(path, g.use("fmt")+".Sprint("+k+")")
+		fw.printf("var %s %s\n", outKey, g.typeString(dstU.Key()))
+		if err := g.assign(fw, outKey, dstU.Key(), k, srcMap.Key(), elemPath, false); err != nil {
+			return err
+		}
+		fw.printf("var %s %s\n", outElem, g.typeString(dstU.Elem()))
+		if err := g.assign(fw, outElem, dstU.Elem(), e, srcMap.Elem(), elemPath, false); err != nil {
+			return err
+		}
+		fw.printf("%s[%s] = %s\n", v, outKey, outElem)
+		fw.printf("}\n%s = %s\n", dst, v)
+		if src != fw.nonNil {
+			fw.printf("}\n")
+		}
+	default:
+		switch {
+		case isString(dstT) && !isString(srcT) && isBasic(srcT):
+			return g.formatString(fw, dst, dstT, src, srcT)
+		case types.Identical(srcT, dstT):
+			fw.printf("%s = %s\n", dst, unparen(src))
+		case isNumber(srcT) && isNumber(dstT):
+			g.convertNumber(fw, dst, dstT, src, srcT, src, path)
+		case types.ConvertibleTo(srcT, dstT):
+			fw.printf("%s = %s(%s)\n", dst, g.typeString(dstT), unparen(src))
+		default:
+			return errUnconvertible(srcT, dstT)
+		}
+	}
+	return nil
+}
+
+// elemPath returns the path expression of the element of path, an
+// expression ending with a string literal, at the string expression index.
+func elemPath(path, index string) string {
+	return strings.TrimSuffix(path, `"`) + `[" + ` + index + ` + "]"`
+}
+
+// assignFromPointer dereferences src before copying it into dst.
+func (g *generator) assignFromPointer(fw *funcWriter, dst string, dstT types.Type, src string, ptr *types.Pointer, path string, clearNil bool) error {
+	if src == fw.nonNil {
+		return g.assign(fw, dst, dstT, "(*"+src+")", ptr.Elem(), path, false)
+	}
+	fw.printf("if %s != nil {\n", src)
+	if err := g.assign(fw, dst, dstT, "(*"+src+")", ptr.Elem(), path, clearNil); err != nil {
+		return err
+	}
+	if clearNil {
+		fw.printf("} else {\n%s = %s\n", dst, g.zero(dstT))
+	}
+	fw.printf("}\n")
+	return nil
+}
+
+// parseString writes the string parsing done by parseStringFlexibly, and
+// reports whether dstT is a kind it parses.
+func (g *generator) parseString(fw *funcWriter, dst string, dstT types.Type, src string, srcT types.Type, path string) bool {
+	basic, ok := dstT.Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+	str := unparen(src)
+	if _, ok := srcT.(*types.Basic); !ok {
+		str = "string(" + str + ")"
+	}
+	trimmed := str
+	if g.parseFormats&deepcopy.ParseTrimSpace != 0 {
+		trimmed = g.use("strings") + ".TrimSpace(" + str + ")"
+	}
+	if basic.Kind() == types.Bool {
+		trueCases, falseCases := []string{"t", "true"}, []string{"f", "false"}
+		if g.parseFormats&deepcopy.ParseBoolWords != 0 {
+			trueCases = append(trueCases, "yes", "on", "1")
+			falseCases = append(falseCases, "no", "off", "0")
+		}
+		fw.printf("switch %s.ToLower(%s) {\n", g.use("strings"), trimmed)
+		fw.printf("case %s:\n%s = true\n", boolCases(trueCases, g.trueString), dst)
+		fw.printf("case %s:\n%s = false\n", boolCases(falseCases, g.falseString), dst)
+		fw.printf("default:\nreturn %s\n}\n", g.conversionError(path, src, dst, g.use(deepcopyPath)+".ErrUnconvertible"))
+		return true
+	}
+
+	var parseFunc string
+	var parsedT types.Type
+	switch {
+	case basic.Info()&types.IsUnsigned != 0:
+		parseFunc, parsedT = "ParseUint", types.Typ[types.Uint64]
+	case basic.Info()&types.IsInteger != 0:
+		parseFunc, parsedT = "ParseInt", types.Typ[types.Int64]
+	case basic.Info()&types.IsFloat != 0:
+		parseFunc, parsedT = "ParseFloat", types.Typ[types.Float64]
+	default:
+		return false
+	}
+	duration := isNamed(dstT, "time", "Duration", false) && g.parseFormats&deepcopy.ParseDurations != 0
+	if duration {
+		d := fw.tmp("d")
+		fw.printf("if %s, err := %s.ParseDuration(%s); err == nil {\n%s = %s\n} else {\n", d, g.use("time"), trimmed, dst, d)
+	}
+	v := fw.tmp("v")
+	fw.printf("if %s, err := %s.%s(%s); err != nil {\n", v, g.parseFormatsExpr(), parseFunc, str)
+	fw.printf("return %s\n} else {\n", g.conversionError(path, src, dst, "err"))
+	if g.overflow == deepcopy.OverflowAllow {
+		// parsed strings are range checked even when overflows are allowed
+		g.overflow = deepcopy.OverflowTruncate
+		defer func() { g.overflow = deepcopy.OverflowAllow }()
+	}
+	g.convertNumber(fw, dst, dstT, v, parsedT, src, path)
+	fw.printf("}\n")
+	if duration {
+		fw.printf("}\n")
+	}
+	return true
+}
+
+// parseFormatsExpr returns an expression for the deepcopy.ParseFormat the
+// generated code parses strings with.
+func (g *generator) parseFormatsExpr() string {
+	pkg := g.use(deepcopyPath)
+	if g.parseFormats == deepcopy.ParseAll {
+		return pkg + ".ParseAll"
+	}
+	var names []string
+	for _, format := range parseFormatNames {
+		if g.parseFormats&format.format != 0 {
+			names = append(names, pkg+"."+format.name)
+		}
+	}
+	switch len(names) {
+	case 0:
+		return pkg + ".ParseDefault"
+	case 1:
+		return names[0]
+	}
+	return "(" + strings.Join(names, " | ") + ")"
+}
+
+var parseFormatNames = []struct {
+	format deepcopy.ParseFormat
+	name   string
+}{
+	{deepcopy.ParseTrimSpace, "ParseTrimSpace"},
+	{deepcopy.ParsePrefixes, "ParsePrefixes"},
+	{deepcopy.ParseThousands, "ParseThousands"},
+	{deepcopy.ParseBoolWords, "ParseBoolWords"},
+	{deepcopy.ParseDurations, "ParseDurations"},
+}
+
+// formatString writes the formatting of booleans and numbers done by
+// Copier.formatString in the deepcopy package.
+func (g *generator) formatString(fw *funcWriter, dst string, dstT types.Type, src string, srcT types.Type) error {
+	basic := srcT.Underlying().(*types.Basic)
+	strconvPkg := g.use("strconv")
+	var format string
+	switch {
+	case isNamed(srcT, "time", "Duration", false) && g.parseFormats&deepcopy.ParseDurations != 0:
+		format = unparen(src) + ".String()"
+	case basic.Info()&types.IsBoolean != 0:
+		fw.printf("if %s {\n%s = %q\n} else {\n%s = %q\n}\n", src, dst, g.trueString, dst, g.falseString)
+		return nil
+	case basic.Info()&types.IsUnsigned != 0:
+		format = fmt.Sprintf("%s.FormatUint(uint64(%s), 10)", strconvPkg, unparen(src))
+	case basic.Info()&types.IsInteger != 0:
+		format = fmt.Sprintf("%s.FormatInt(int64(%s), 10)", strconvPkg, unparen(src))
+	case basic.Info()&types.IsFloat != 0:
+		bitSize := 64
+		if basic.Kind() == types.Float32 {
+			bitSize = 32
+		}
+		format = fmt.Sprintf("%s.FormatFloat(float64(%s), %q, %d, %d)", strconvPkg, unparen(src), g.floatFormat, g.floatPrecision, bitSize)
+	default:
+		return errUnconvertible(srcT, dstT)
+	}
+	if _, ok := dstT.(*types.Basic); !ok {
+		format = g.typeString(dstT) + "(" + format + ")"
+	}
+	fw.printf("%s = %s\n", dst, format)
+	return nil
+}
+
+// number describes the range of a basic numeric type. int, uint and
+// uintptr are between minBits and maxBits wide depending on the platform.
+type number struct {
+	class   byte // 'i', 'u' or 'f'
+	minBits int
+	maxBits int
+	// name is the suffix of the type's limits in the math package.
+	name string
+}
+
+func numberOf(t types.Type) (number, bool) {
+	basic, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return number{}, false
+	}
+	switch basic.Kind() {
+	case types.Int:
+		return number{'i', 32, 64, "Int"}, true
+	case types.Int8:
+		return number{'i', 8, 8, "Int8"}, true
+	case types.Int16:
+		return number{'i', 16, 16, "Int16"}, true
+	case types.Int32:
+		return number{'i', 32, 32, "Int32"}, true
+	case types.Int64:
+		return number{'i', 64, 64, "Int64"}, true
+	case types.Uint, types.Uintptr:
+		return number{'u', 32, 64, "Uint"}, true
+	case types.Uint8:
+		return number{'u', 8, 8, "Uint8"}, true
+	case types.Uint16:
+		return number{'u', 16, 16, "Uint16"}, true
+	case types.Uint32:
+		return number{'u', 32, 32, "Uint32"}, true
+	case types.Uint64:
+		return number{'u', 64, 64, "Uint64"}, true
+	case types.Float32:
+		return number{'f', 32, 32, "Float32"}, true
+	case types.Float64:
+		return number{'f', 64, 64, "Float64"}, true
+	}
+	return number{}, false
+}
+
+func isNumber(t types.Type) bool {
+	_, ok := numberOf(t)
+	return ok
+}
+
+// convertNumber writes the range and precision checks done by
+// Copier.convertNumber in the deepcopy package, reporting errValue as the
+// value that failed. The source is widened to int64, uint64 or float64,
+// with floats truncated, and compared against the destination's limits.
+func (g *generator) convertNumber(fw *funcWriter, dst string, dstT types.Type, src string, srcT types.Type, errValue, path string) {
+	in, _ := numberOf(srcT)
+	out, _ := numberOf(dstT)
+	if g.overflow == deepcopy.OverflowAllow {
+		fw.printf("%s = %s(%s)\n", dst, g.typeString(dstT), unparen(src))
+		return
+	}
+
+	v := fw.tmp("v")
+	wide := map[byte]string{'i': "int64", 'u': "uint64", 'f': "float64"}[in.class]
+	value := unparen(src)
+	if basic, ok := srcT.(*types.Basic); !ok || basic.Name() != wide {
+		value = wide + "(" + value + ")"
+	}
+	if in.class == 'f' && out.class != 'f' {
+		value = fmt.Sprintf("%s.Trunc(%s)", g.use("math"), value)
+	}
+	errOverflow := "return " + g.conversionError(path, errValue, dst, g.use(deepcopyPath)+".ErrOverflow")
+
+	// each case holds a condition and the statement run when it is true
+	var cases [][2]string
+	outOfRange := func(cond, bound string) {
+		if g.overflow == deepcopy.OverflowSaturate {
+			cases = append(cases, [2]string{cond, dst + " = " + bound})
+		} else {
+			cases = append(cases, [2]string{cond, errOverflow})
+		}
+	}
+	// limit returns the destination's minimum or maximum
+	limit := func(prefix string) string {
+		if prefix == "Min" && out.class == 'u' {
+			return "0"
+		}
+		return g.use("math") + "." + prefix + out.name
+	}
+	switch out.class {
+	case 'i', 'u':
+		switch in.class {
+		case 'f':
+			if g.overflow == deepcopy.OverflowError {
+				cases = append(cases, [2]string{v + " != float64(" + unparen(src) + ")", errOverflow})
+			} else {
+				cases = append(cases, [2]string{g.use("math") + ".IsNaN(" + v + ")", errOverflow})
+			}
+			outOfRange(v+" < "+limit("Min"), limit("Min"))
+			outOfRange(v+" >= "+limit("Max")+"+1", limit("Max"))
+		case 'i':
+			if out.class == 'u' {
+				outOfRange(v+" < 0", limit("Min"))
+				if in.maxBits-1 > out.minBits {
+					outOfRange("uint64("+v+") > "+limit("Max"), limit("Max"))
+				}
+			} else if in.maxBits > out.minBits {
+				outOfRange(v+" < "+limit("Min"), limit("Min"))
+				outOfRange(v+" > "+limit("Max"), limit("Max"))
+			}
+		case 'u':
+			if in.maxBits > out.minBits || out.class == 'i' && in.maxBits == out.minBits {
+				outOfRange(v+" > "+limit("Max"), limit("Max"))
+			}
+		}
+	case 'f':
+		if in.class == 'f' {
+			if out.maxBits == 32 && in.maxBits == 64 {
+				mathPkg := g.use("math")
+				max := mathPkg + ".MaxFloat32"
+				outOfRange(fmt.Sprintf("%s > %s && !%s.IsInf(%s, 1)", v, max, mathPkg, v), max)
+				outOfRange(fmt.Sprintf("%s < -%s && !%s.IsInf(%s, -1)", v, max, mathPkg, v), "-"+max)
+			}
+			break
+		}
+		// integers beyond ±2^mantissa might not be represented exactly
+		mantissa := 53
+		if out.maxBits == 32 {
+			mantissa = 24
+		}
+		bits := in.maxBits
+		if in.class == 'i' {
+			bits--
+		}
+		if g.overflow == deepcopy.OverflowError && bits > mantissa {
+			if in.class == 'i' {
+				cases = append(cases, [2]string{fmt.Sprintf("%s < -1<<%d", v, mantissa), errOverflow})
+			}
+			cases = append(cases, [2]string{fmt.Sprintf("%s > 1<<%d", v, mantissa), errOverflow})
+		}
+	}
+
+	if len(cases) == 0 {
+		fw.printf("%s = %s(%s)\n", dst, g.typeString(dstT), unparen(src))
+		return
+	}
+	fw.printf("switch %s := %s; {\n", v, value)
+	for _, c := range cases {
+		fw.printf("case %s:\n%s\n", c[0], c[1])
+	}
+	fw.printf("default:\n%s = %s(%s)\n}\n", dst, g.typeString(dstT), v)
+}
+
+// boolCases returns the case clause values matching a boolean, adding the
+// configured string to the default ones.
+func boolCases(defaults []string, configured string) string {
+	cases := make([]string, 0, len(defaults)+1)
+	configured = strings.ToLower(configured)
+	for _, c := range defaults {
+		if c == configured {
+			configured = ""
+		}
+		cases = append(cases, strconv.Quote(c))
+	}
+	if configured != "" {
+		cases = append(cases, strconv.Quote(configured))
+	}
+	return strings.Join(cases, ", ")
+}
+
+// conversionError returns an expression building a *deepcopy.ConversionError
+// for the value src that could not be copied into dst.
+func (g *generator) conversionError(path, src, dst, cause string) string {
+	reflectPkg := g.use("reflect")
+	return fmt.Sprintf("&%s.ConversionError{Path: %s, Value: %s, SrcType: %s.TypeOf(%s), DstType: %s.TypeOf(%s), Err: %s}",
+		g.use(deepcopyPath), path, src, reflectPkg, src, reflectPkg, dst, cause)
+}
+
+// nonZero returns a boolean expression that is true when expr of type t
+// is not the zero value, like reflect.Value.IsZero.
+func (g *generator) nonZero(expr string, t types.Type) string {
+	return g.compareZero(expr, t, false)
+}
+
+// isZero returns a boolean expression that is true when expr of type t
+// is the zero value.
+func (g *generator) isZero(expr string, t types.Type) string {
+	return g.compareZero(expr, t, true)
+}
+
+func (g *generator) compareZero(expr string, t types.Type, equal bool) string {
+	op := "!="
+	if equal {
+		op = "=="
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			if equal {
+				return "!" + expr
+			}
+			return expr
+		case u.Info()&types.IsString != 0:
+			return fmt.Sprintf(`%s %s ""`, expr, op)
+		case u.Kind() == types.UnsafePointer:
+			return fmt.Sprintf("%s %s nil", expr, op)
+		default:
+			return fmt.Sprintf("%s %s 0", expr, op)
+		}
+	case *types.Struct, *types.Array:
+		if types.Comparable(t) {
+			return fmt.Sprintf("%s %s (%s{})", expr, op, g.typeString(t))
+		}
+		isZero := fmt.Sprintf("%s.ValueOf(%s).IsZero()", g.use("reflect"), expr)
+		if equal {
+			return isZero
+		}
+		return "!" + isZero
+	default:
+		return fmt.Sprintf("%s %s nil", expr, op)
+	}
+}
+
+// zero returns the zero value expression for t.
+func (g *generator) zero(t types.Type) string {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "false"
+		case u.Info()&types.IsString != 0:
+			return `""`
+		case u.Kind() == types.UnsafePointer:
+			return "nil"
+		default:
+			return "0"
+		}
+	case *types.Struct, *types.Array:
+		return g.typeString(t) + "{}"
+	default:
+		return "nil"
+	}
+}
+
+// use records that the generated code refers to the package at path and
+// returns the name it is imported as.
+func (g *generator) use(path string) string {
+	if name, ok := g.imports[path]; ok {
+		return name
+	}
+	name := filepath.Base(path)
+	for taken := true; taken; {
+		taken = false
+		for _, other := range g.imports {
+			if other == name {
+				taken = true
+				name += "_"
+				break
+			}
+		}
+	}
+	g.imports[path] = name
+	return name
+}
+
+func (g *generator) qualifier(pkg *types.Package) string {
+	if pkg == g.pkg {
+		return ""
+	}
+	return g.use(pkg.Path())
+}
+
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, g.qualifier)
+}
+
+// typeIdent returns an identifier for a named type, prefixed with its
+// package name when it is declared in another package.
+func (g *generator) typeIdent(named *types.Named) string {
+	name := named.Obj().Name()
+	if pkg := named.Obj().Pkg(); pkg != g.pkg {
+		pkgName := []rune(pkg.Name())
+		pkgName[0] = unicode.ToUpper(pkgName[0])
+		name = string(pkgName) + name
+	}
+	return name
+}
+
+// funcWriter accumulates the body of a generated function.
+type funcWriter struct {
+	buf  bytes.Buffer
+	tmps int
+	// nonNil is an expression already checked to be non-nil.
+	nonNil string
+}
+
+func (fw *funcWriter) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&fw.buf, format, args...)
+}
+
+// tmp returns a variable name that is unique within the function.
+func (fw *funcWriter) tmp(prefix string) string {
+	fw.tmps++
+	return prefix + strconv.Itoa(fw.tmps)
+}
+
+// addr returns an expression for the address of expr.
+func addr(expr string) string {
+	if strings.HasPrefix(expr, "(*") && strings.HasSuffix(expr, ")") {
+		return expr[2 : len(expr)-1]
+	}
+	return "&" + expr
+}
+
+// unparen drops the parentheses around a dereference expression.
+func unparen(expr string) string {
+	if strings.HasPrefix(expr, "(*") && strings.HasSuffix(expr, ")") {
+		return expr[1 : len(expr)-1]
+	}
+	return expr
+}
+
+func errUnconvertible(srcT, dstT types.Type) error {
+	return fmt.Errorf("%w %s to %s", deepcopy.ErrUnconvertible, srcT, dstT)
+}
+
+func isNamed(t types.Type, pkgPath, name string, pointer bool) bool {
+	if pointer {
+		ptr, ok := t.(*types.Pointer)
+		if !ok {
+			return false
+		}
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Path() == pkgPath && named.Obj().Name() == name
+}
+
+func isInterface(t types.Type) bool {
+	return types.IsInterface(t)
+}
+
+func isBasic(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Basic)
+	return ok
+}
+
+func isString(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
+
+// elemType returns the element type of a slice or array type.
+func elemType(t types.Type) (types.Type, bool) {
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		return u.Elem(), true
+	case *types.Array:
+		return u.Elem(), true
+	}
+	return nil, false
+}
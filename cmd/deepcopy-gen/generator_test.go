@@ -0,0 +1,90 @@
+package main
+
+import (
+	"github.com/fluidtruck/deepcopy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var defaultConfig = config{
+	floatFormat:    'f',
+	floatPrecision: -1,
+	trueString:     "true",
+	falseString:    "false",
+}
+
+func TestGenerateUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..", "internal", "gentest")
+	pairs := []typePair{
+		{src: "PbUser", dst: "User", funcName: "ConvertPbUserToUser"},
+		{src: "Address", dst: "PbAddress"},
+		{src: "User", dst: "UserSummary"},
+		{src: "UserSummary", dst: "User"},
+	}
+	src, err := generate(dir, "deepcopy_gen.go", pairs, defaultConfig)
+	require.NoError(t, err)
+
+	expected, err := os.ReadFile(filepath.Join(dir, "deepcopy_gen.go"))
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(src), "run go generate ./internal/gentest")
+}
+
+func TestGenerateErrors(t *testing.T) {
+	dir := filepath.Join("..", "..", "internal", "gentest")
+	testCases := []struct {
+		name        string
+		dir         string
+		pairs       []typePair
+		expectedErr string
+	}{
+		{
+			name:        "unknown type",
+			pairs:       []typePair{{src: "PbUser", dst: "Customer"}},
+			expectedErr: "type Customer not found",
+		},
+		{
+			name:        "unconvertible field",
+			dir:         filepath.Join("testdata", "unconvertible"),
+			pairs:       []typePair{{src: "Event", dst: "Record"}},
+			expectedErr: "unconvertible.Event.At: unable to convert time.Time to []string",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := dir
+			if tc.dir != "" {
+				dir = tc.dir
+			}
+			_, err := generate(dir, "deepcopy_gen.go", tc.pairs, defaultConfig)
+			require.Error(t, err)
+			assert.Equal(t, tc.expectedErr, err.Error())
+		})
+	}
+}
+
+func TestGenerateInterfaceCopier(t *testing.T) {
+	dir := filepath.Join("..", "..", "internal", "gentest")
+	pairs := []typePair{{src: "PbUser", dst: "User"}}
+	cfg := defaultConfig
+	cfg.tagName = "json"
+	cfg.naming = []namingOption{
+		{strategy: deepcopy.StripPrefix("Pb"), name: "StripPrefix", args: []string{"Pb"}},
+		{strategy: deepcopy.SnakeCase, name: "SnakeCase"},
+	}
+	cfg.overflow = deepcopy.OverflowSaturate
+	cfg.parseFormats = deepcopy.ParseTrimSpace | deepcopy.ParseBoolWords
+	src, err := generate(dir, "deepcopy_gen.go", pairs, cfg)
+	require.NoError(t, err)
+	assert.Contains(t, string(src), `var deepcopyCopier = deepcopy.New(
+	deepcopy.WithTagName("json"),
+	deepcopy.WithNamingStrategy(deepcopy.StripPrefix("Pb"), deepcopy.SnakeCase),
+	deepcopy.WithOverflowPolicy(deepcopy.OverflowSaturate),
+	deepcopy.WithParseFormats(deepcopy.ParseTrimSpace|deepcopy.ParseBoolWords),
+)`)
+	assert.Contains(t, string(src), `if err := deepcopyCopier.Copy(in.Metadata, &out.Metadata); err != nil {`)
+	// fields are matched with the same options, so the dc tag is ignored
+	assert.NotContains(t, string(src), "out.Name = in.FullName")
+}
@@ -0,0 +1,166 @@
+// Command deepcopy-gen generates conversion functions that copy one struct
+// type into another exactly like deepcopy.DeepCopy, but without reflection.
+//
+// Each -type flag declares a pair of struct types as Src:Dst, or
+// Src:Dst:FuncName to choose the generated function's name. Types declared
+// in the current package are referred to by name; other types are referred
+// to by import path and name:
+//
+//	//go:generate deepcopy-gen -type github.com/acme/api/pb.User:User:ConvertUserPBToUser
+//
+// generates
+//
+//	func ConvertUserPBToUser(in *pb.User, out *User) error
+//
+// Fields are matched with the same rules as DeepCopy, including the "dc"
+// tag and fields promoted from exported embedded structs, and nested struct
+// types get their own conversion functions. Values held in interfaces are
+// still copied at run time, by a deepcopy.Copier created with the same
+// options, or by DeepCopy when every option is left at its default.
+// Elsewhere, converters registered with deepcopy.RegisterConverter are not
+// known to the generated code, fields of unexported embedded structs are
+// not copied, and neither shared pointers nor reference cycles are
+// preserved. Numbers copied into or from durations are always counted in
+// nanoseconds.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/fluidtruck/deepcopy"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var namingStrategies = map[string]namingOption{
+	"snake":    {strategy: deepcopy.SnakeCase, name: "SnakeCase"},
+	"camel":    {strategy: deepcopy.CamelCase, name: "CamelCase"},
+	"acronyms": {strategy: deepcopy.NormalizeAcronyms, name: "NormalizeAcronyms"},
+}
+
+var parseFormats = map[string]deepcopy.ParseFormat{
+	"space":     deepcopy.ParseTrimSpace,
+	"prefixes":  deepcopy.ParsePrefixes,
+	"thousands": deepcopy.ParseThousands,
+	"bools":     deepcopy.ParseBoolWords,
+	"durations": deepcopy.ParseDurations,
+	"all":       deepcopy.ParseAll,
+}
+
+var overflowPolicies = map[string]deepcopy.OverflowPolicy{
+	"error":    deepcopy.OverflowError,
+	"truncate": deepcopy.OverflowTruncate,
+	"saturate": deepcopy.OverflowSaturate,
+	"allow":    deepcopy.OverflowAllow,
+}
+
+type pairsFlag []typePair
+
+func (p *pairsFlag) String() string {
+	pairs := make([]string, len(*p))
+	for i, pair := range *p {
+		pairs[i] = pair.String()
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (p *pairsFlag) Set(value string) error {
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 && len(parts) != 3 {
+		return fmt.Errorf("expected Src:Dst or Src:Dst:FuncName, got %q", value)
+	}
+	pair := typePair{src: parts[0], dst: parts[1]}
+	if len(parts) == 3 {
+		pair.funcName = parts[2]
+	}
+	*p = append(*p, pair)
+	return nil
+}
+
+func main() {
+	var pairs pairsFlag
+	flag.Var(&pairs, "type", "struct type pair to convert, as Src:Dst or Src:Dst:FuncName (repeatable)")
+	output := flag.String("output", "deepcopy_gen.go", "output file name")
+	tagName := flag.String("tag", deepcopy.DC_STRUCT_TAG, "struct tag used to manually match fields")
+	fallbackTags := flag.String("fallback-tags", "", "comma-separated struct tags consulted in order when the dc tag has no name, such as json,protobuf")
+	caseSensitive := flag.Bool("case-sensitive", false, "match field names and tags case-sensitively")
+	naming := flag.String("naming", "", "comma-separated naming strategies applied before matching: snake, camel, acronyms")
+	stripPrefix := flag.String("strip-prefix", "", "comma-separated prefixes stripped from names before matching")
+	stripSuffix := flag.String("strip-suffix", "", "comma-separated suffixes stripped from names before matching")
+	floatFormat := flag.String("float-format", "f", "strconv.FormatFloat format used to copy floats into strings")
+	floatPrecision := flag.Int("float-precision", -1, "strconv.FormatFloat precision used to copy floats into strings")
+	boolStrings := flag.String("bool-strings", "true,false", "strings that true and false are copied into, as true,false")
+	parse := flag.String("parse", "", "comma-separated string formats parsed into numbers, booleans and durations: space, prefixes, thousands, bools, durations or all")
+	overflow := flag.String("overflow", "error", "handling of numeric conversions that overflow or lose precision: error, truncate, saturate or allow")
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("deepcopy-gen: ")
+	if len(pairs) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var fallbacks []string
+	if *fallbackTags != "" {
+		fallbacks = strings.Split(*fallbackTags, ",")
+	}
+	var strategies []namingOption
+	if *stripPrefix != "" {
+		prefixes := strings.Split(*stripPrefix, ",")
+		strategies = append(strategies, namingOption{strategy: deepcopy.StripPrefix(prefixes...), name: "StripPrefix", args: prefixes})
+	}
+	if *stripSuffix != "" {
+		suffixes := strings.Split(*stripSuffix, ",")
+		strategies = append(strategies, namingOption{strategy: deepcopy.StripSuffix(suffixes...), name: "StripSuffix", args: suffixes})
+	}
+	if *naming != "" {
+		for _, name := range strings.Split(*naming, ",") {
+			strategy, ok := namingStrategies[name]
+			if !ok {
+				log.Fatalf("unknown naming strategy %q", name)
+			}
+			strategies = append(strategies, strategy)
+		}
+	}
+	overflowPolicy, ok := overflowPolicies[*overflow]
+	if !ok {
+		log.Fatalf("unknown overflow policy %q", *overflow)
+	}
+	var formats deepcopy.ParseFormat
+	if *parse != "" {
+		for _, name := range strings.Split(*parse, ",") {
+			format, ok := parseFormats[name]
+			if !ok {
+				log.Fatalf("unknown parse format %q", name)
+			}
+			formats |= format
+		}
+	}
+	boolParts := strings.Split(*boolStrings, ",")
+	if len(*floatFormat) != 1 || len(boolParts) != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	cfg := config{
+		tagName:        *tagName,
+		fallbackTags:   fallbacks,
+		caseSensitive:  *caseSensitive,
+		naming:         strategies,
+		floatFormat:    (*floatFormat)[0],
+		floatPrecision: *floatPrecision,
+		trueString:     boolParts[0],
+		falseString:    boolParts[1],
+		overflow:       overflowPolicy,
+		parseFormats:   formats,
+	}
+	src, err := generate(".", filepath.Base(*output), pairs, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
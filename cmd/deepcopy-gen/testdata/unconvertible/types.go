@@ -0,0 +1,11 @@
+package unconvertible
+
+import "time"
+
+type Event struct {
+	At time.Time
+}
+
+type Record struct {
+	At []string
+}
@@ -0,0 +1,86 @@
+package deepcopy
+
+import (
+	"reflect"
+)
+
+// converterFunc converts a value of a registered source type into a value
+// of the registered destination type.
+type converterFunc func(in reflect.Value) (reflect.Value, error)
+
+type typePair struct {
+	src reflect.Type
+	dst reflect.Type
+}
+
+// WithConverter registers fn as the conversion from S to D. Registered
+// converters are consulted before any built-in conversion, at every
+// nesting level, including slice elements and pointer chains.
+func WithConverter[S, D any](fn func(S) (D, error)) Option {
+	return func(c *Copier) {
+		registerConverter(c, fn)
+	}
+}
+
+// RegisterConverter registers fn as the conversion from S to D used by
+// DeepCopy. Registering a second converter for the same pair replaces the
+// first.
+func RegisterConverter[S, D any](fn func(S) (D, error)) {
+	registerConverter(defaultCopier, fn)
+}
+
+func registerConverter[S, D any](c *Copier, fn func(S) (D, error)) {
+	pair := typePair{
+		src: reflect.TypeOf((*S)(nil)).Elem(),
+		dst: reflect.TypeOf((*D)(nil)).Elem(),
+	}
+	conv := func(in reflect.Value) (reflect.Value, error) {
+		out, err := fn(in.Interface().(S))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(&out).Elem(), nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.converters == nil {
+		c.converters = make(map[typePair]converterFunc)
+	}
+	c.converters[pair] = conv
+	// cached plans may hold converters chosen before this one
+	c.plans = nil
+}
+
+// lookupConverter returns the converter registered for inValue's type (or a
+// pointer to it) and outType, along with the value it should be called with.
+func (c *Copier) lookupConverter(inValue reflect.Value, outType reflect.Type) (converterFunc, reflect.Value, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.converters) == 0 {
+		return nil, inValue, false
+	}
+	if conv, ok := c.converters[typePair{src: inValue.Type(), dst: outType}]; ok {
+		return conv, inValue, true
+	}
+	// inputs arrive dereferenced, so fall back to a converter registered
+	// for a pointer to the input type
+	if conv, ok := c.converters[typePair{src: reflect.PtrTo(inValue.Type()), dst: outType}]; ok {
+		if inValue.CanAddr() {
+			return conv, inValue.Addr(), true
+		}
+		inValuePtr := reflect.New(inValue.Type())
+		inValuePtr.Elem().Set(inValue)
+		return conv, inValuePtr, true
+	}
+	return nil, inValue, false
+}
+
+func (c *Copier) convertWithConverter(path string, conv converterFunc, inValue, outValue reflect.Value) error {
+	converted, err := conv(inValue)
+	if err != nil {
+		return newConversionError(path, inValue, outValue.Type(), err)
+	}
+	outValue.Set(converted)
+	return nil
+}
@@ -0,0 +1,132 @@
+package deepcopy
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+type Money struct {
+	cents int64
+}
+
+type Vin struct {
+	value string
+}
+
+type LocalCharge struct {
+	Amount  Money
+	Refunds []Money
+	Fee     *Money
+	Vin     *Vin
+}
+
+type DbCharge struct {
+	Amount  int64
+	Refunds []*int64
+	Fee     **int64
+	Vin     string
+}
+
+type RegisteredA struct {
+	Vin Vin
+}
+
+type RegisteredB struct {
+	Vin string
+}
+
+func moneyToCents(m Money) (int64, error) {
+	return m.cents, nil
+}
+
+func vinToString(v *Vin) (string, error) {
+	if len(v.value) != 17 {
+		return "", errors.New("vin must be 17 characters")
+	}
+	return strings.ToUpper(v.value), nil
+}
+
+func TestConverters(t *testing.T) {
+	copier := New(
+		WithConverter(moneyToCents),
+		WithConverter(vinToString),
+	)
+	fee := int64(150)
+	feePtr := &fee
+	refund := int64(25)
+
+	testCases := []struct {
+		name            string
+		input           interface{}
+		outputPtr       interface{}
+		expectedRespPtr interface{}
+		expectedErr     error
+	}{
+		{
+			name: "converter used for struct field",
+			input: LocalCharge{
+				Amount: Money{cents: 1000},
+			},
+			outputPtr: &DbCharge{},
+			expectedRespPtr: &DbCharge{
+				Amount: 1000,
+			},
+		},
+		{
+			name: "converter used for slice elements and pointer chains",
+			input: LocalCharge{
+				Refunds: []Money{{cents: 25}},
+				Fee:     &Money{cents: 150},
+			},
+			outputPtr: &DbCharge{},
+			expectedRespPtr: &DbCharge{
+				Refunds: []*int64{&refund},
+				Fee:     &feePtr,
+			},
+		},
+		{
+			name: "converter registered for pointer source",
+			input: LocalCharge{
+				Vin: &Vin{value: "1hgcm82633a004352"},
+			},
+			outputPtr: &DbCharge{},
+			expectedRespPtr: &DbCharge{
+				Vin: "1HGCM82633A004352",
+			},
+		},
+		{
+			name: "converter error is returned",
+			input: LocalCharge{
+				Vin: &Vin{value: "short"},
+			},
+			outputPtr:   &DbCharge{},
+			expectedErr: errors.New("Vin: unable to convert &{short} (type *deepcopy.Vin) to type string: vin must be 17 characters"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := copier.Copy(tc.input, tc.outputPtr)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedRespPtr, tc.outputPtr)
+			}
+		})
+	}
+}
+
+func TestRegisterConverter(t *testing.T) {
+	out := RegisteredB{}
+	err := DeepCopy(RegisteredA{Vin: Vin{value: "1hgcm82633a004352"}}, &out)
+	require.Error(t, err)
+
+	RegisterConverter(vinToString)
+	err = DeepCopy(RegisteredA{Vin: Vin{value: "1hgcm82633a004352"}}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, RegisteredB{Vin: "1HGCM82633A004352"}, out)
+}
@@ -0,0 +1,243 @@
+package deepcopy
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Copier copies values according to a fixed set of options. A Copier is
+// safe for concurrent use once it has been created with New.
+type Copier struct {
+	tagName       string
+	fallbackTags  []string
+	zeroPolicy    ZeroPolicy
+	caseSensitive bool
+	naming        []NamingStrategy
+	timeLocation  *time.Location
+	collectErrors bool
+	maxErrors     int
+	cycleError    bool
+	lengthPolicy  LengthPolicy
+	strictMode    StrictMode
+	// overflowPolicy controls numeric conversions that do not fit.
+	overflowPolicy OverflowPolicy
+	// parseFormats selects the string formats parsed into numbers.
+	parseFormats ParseFormat
+	// durationUnit is the unit of numbers copied into or from durations.
+	durationUnit time.Duration
+	// floatFormat, floatPrecision, trueString and falseString control
+	// how numbers and booleans are formatted as strings.
+	floatFormat    byte
+	floatPrecision int
+	trueString     string
+	falseString    string
+
+	mu         sync.RWMutex
+	converters map[typePair]converterFunc
+	plans      map[typePair]*structPlan
+}
+
+// Option configures a Copier.
+type Option func(*Copier)
+
+// New returns a Copier configured with the given options. Without options,
+// the Copier behaves exactly like DeepCopy.
+func New(opts ...Option) *Copier {
+	c := &Copier{
+		tagName:        DC_STRUCT_TAG,
+		floatFormat:    'f',
+		floatPrecision: -1,
+		trueString:     "true",
+		falseString:    "false",
+		durationUnit:   time.Nanosecond,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithTagName sets the struct tag used to manually match fields.
+// Defaults to DC_STRUCT_TAG ("dc").
+func WithTagName(name string) Option {
+	return func(c *Copier) {
+		c.tagName = name
+	}
+}
+
+// ZeroPolicy controls whether source fields holding the zero value for
+// their type are copied. Fields tagged with the "copyzero" or "omitempty"
+// tag options ignore the policy.
+type ZeroPolicy uint8
+
+const (
+	// ZeroSkip skips zero-valued source fields, leaving the destination
+	// field untouched.
+	ZeroSkip ZeroPolicy = iota
+	// ZeroCopy copies every matching field, overwriting destination fields
+	// with zero values.
+	ZeroCopy
+	// ZeroPresence treats pointer destination fields as presence markers:
+	// a zero non-pointer source field is copied into a pointer destination
+	// field, which becomes a non-nil pointer to the zero value. All other
+	// zero-valued source fields, including nil pointers, are skipped.
+	ZeroPresence
+)
+
+// WithZeroPolicy sets whether zero-valued source fields are copied.
+// Defaults to ZeroSkip.
+func WithZeroPolicy(policy ZeroPolicy) Option {
+	return func(c *Copier) {
+		c.zeroPolicy = policy
+	}
+}
+
+// WithZeroValues copies source fields even when they hold the zero value
+// for their type, overwriting whatever the destination field contained.
+// It is shorthand for WithZeroPolicy(ZeroCopy).
+func WithZeroValues() Option {
+	return WithZeroPolicy(ZeroCopy)
+}
+
+// WithCaseSensitiveNames requires field names and tags to match exactly
+// instead of case-insensitively.
+func WithCaseSensitiveNames() Option {
+	return func(c *Copier) {
+		c.caseSensitive = true
+	}
+}
+
+// WithTimeLocation sets the location of time.Time values converted from
+// *timestamppb.Timestamp. Defaults to UTC.
+func WithTimeLocation(loc *time.Location) Option {
+	return func(c *Copier) {
+		c.timeLocation = loc
+	}
+}
+
+// WithCollectErrors makes Copy continue past values that fail to convert,
+// leaving them unset, and return every failure together as Errors. Copying
+// stops once limit errors have been collected; a limit of 0 or less
+// collects every error.
+func WithCollectErrors(limit int) Option {
+	return func(c *Copier) {
+		c.collectErrors = true
+		c.maxErrors = limit
+	}
+}
+
+// WithCycleError makes Copy fail with ErrCycle when the input contains a
+// reference cycle. By default, cycles are reproduced in the output.
+func WithCycleError() Option {
+	return func(c *Copier) {
+		c.cycleError = true
+	}
+}
+
+// LengthPolicy controls copies between arrays, or from slices into arrays,
+// whose lengths differ. Policies can be combined with |.
+type LengthPolicy uint8
+
+const (
+	// LengthStrict fails when the lengths differ.
+	LengthStrict LengthPolicy = 0
+	// LengthTruncate drops source elements that do not fit in the
+	// destination array.
+	LengthTruncate LengthPolicy = 1 << 0
+	// LengthZeroPad leaves destination array elements beyond the source's
+	// length at their zero value.
+	LengthZeroPad LengthPolicy = 1 << 1
+)
+
+// WithLengthPolicy sets how arrays of mismatched lengths are copied.
+// Defaults to LengthStrict.
+func WithLengthPolicy(policy LengthPolicy) Option {
+	return func(c *Copier) {
+		c.lengthPolicy = policy
+	}
+}
+
+// WithFloatFormat sets how floats are formatted when copied into strings,
+// using the format and precision arguments of strconv.FormatFloat. Defaults
+// to 'f' and -1, the fewest digits that represent the value exactly.
+func WithFloatFormat(format byte, precision int) Option {
+	return func(c *Copier) {
+		c.floatFormat = format
+		c.floatPrecision = precision
+	}
+}
+
+// WithBoolStrings sets the strings that true and false are copied into,
+// such as "yes" and "no". Both are also accepted, case-insensitively, when
+// copying strings into booleans. Defaults to "true" and "false".
+func WithBoolStrings(trueString, falseString string) Option {
+	return func(c *Copier) {
+		c.trueString = trueString
+		c.falseString = falseString
+	}
+}
+
+// Copy recursively copies input into output, which must be a pointer.
+func (c *Copier) Copy(input, output interface{}) error {
+	inputVal := reflect.ValueOf(input)
+	outputVal := reflect.ValueOf(output)
+	if outputVal.Kind() != reflect.Ptr {
+		errOutValueNotPtr := fmt.Errorf("%w for arg1 %s but received %s", ErrNotPointer, outputVal, outputVal.Kind())
+		return errOutValueNotPtr
+	}
+	if outputVal.IsNil() {
+		return fmt.Errorf("%w for arg1 but received nil %s", ErrNotPointer, outputVal.Type())
+	}
+	outputPtr := outputVal
+	outputVal = outputVal.Elem()
+	// the input argument is dereferenced even when output is an interface
+	inputVal = dereferenceInput(inputVal, outputVal)
+	s := &copyState{Copier: c}
+	// references back to the input resolve to the output pointer
+	var v *visit
+	if key, tracked := visitKeyFor(inputVal, outputPtr.Type()); tracked {
+		v = s.startVisit(key, outputPtr)
+	}
+	err := s.smartCopy("", inputVal, outputVal)
+	if v != nil {
+		v.done = true
+	}
+	if err != nil && err != errErrorLimit {
+		err = s.handleError(err)
+	}
+	if err == nil && s.unmatched != nil {
+		err = s.handleError(s.unmatched)
+	}
+	if len(s.errs) > 0 {
+		return s.errs
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// copyState holds the state of a single call to Copy.
+type copyState struct {
+	*Copier
+	errs    Errors
+	visited map[visitKey]*visit
+	// reported holds the struct type pairs checked for unmatched fields.
+	reported  map[typePair]bool
+	unmatched *UnmatchedFieldsError
+}
+
+// handleError records err when collecting errors. It returns the error
+// that should abort the copy, or nil if copying should continue.
+func (s *copyState) handleError(err error) error {
+	if !s.collectErrors || err == errErrorLimit {
+		return err
+	}
+	s.errs = append(s.errs, err)
+	if s.maxErrors > 0 && len(s.errs) >= s.maxErrors {
+		return errErrorLimit
+	}
+	return nil
+}
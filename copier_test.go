@@ -0,0 +1,135 @@
+package deepcopy
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+type OptionsA struct {
+	Name     string `json:"fullname"`
+	IsActive bool
+	Count    int
+}
+
+type OptionsB struct {
+	FullName string
+	IsActive bool
+	Count    int
+}
+
+type OptionsTime struct {
+	Time *time.Time
+}
+
+type OptionsTimestamp struct {
+	Time *timestamppb.Timestamp
+}
+
+func TestCopierOptions(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+	require.NoError(t, err)
+	time1 := time.Date(2022, 8, 17, 12, 0, 0, 0, time.UTC)
+	time1Denver := time1.In(denver)
+
+	testCases := []struct {
+		name            string
+		copier          *Copier
+		input           interface{}
+		outputPtr       interface{}
+		expectedRespPtr interface{}
+	}{
+		{
+			name:   "default copier skips zero values",
+			copier: New(),
+			input: OptionsB{
+				IsActive: false,
+				Count:    4,
+			},
+			outputPtr: &OptionsB{
+				IsActive: true,
+				Count:    2,
+			},
+			expectedRespPtr: &OptionsB{
+				IsActive: true,
+				Count:    4,
+			},
+		},
+		{
+			name:   "WithZeroValues copies zero values",
+			copier: New(WithZeroValues()),
+			input: OptionsB{
+				IsActive: false,
+				Count:    4,
+			},
+			outputPtr: &OptionsB{
+				FullName: "leia",
+				IsActive: true,
+				Count:    2,
+			},
+			expectedRespPtr: &OptionsB{
+				Count: 4,
+			},
+		},
+		{
+			name:   "WithTagName matches on another tag",
+			copier: New(WithTagName("json")),
+			input: OptionsA{
+				Name: "leia organa",
+			},
+			outputPtr: &OptionsB{},
+			expectedRespPtr: &OptionsB{
+				FullName: "leia organa",
+			},
+		},
+		{
+			name:   "default tag name ignores json tag",
+			copier: New(),
+			input: OptionsA{
+				Name: "leia organa",
+			},
+			outputPtr:       &OptionsB{},
+			expectedRespPtr: &OptionsB{},
+		},
+		{
+			name:   "WithCaseSensitiveNames rejects case-insensitive matches",
+			copier: New(WithCaseSensitiveNames()),
+			input: PbTeacher{
+				TeacherId: uint64(4),
+			},
+			outputPtr:       &LocalTeacher{},
+			expectedRespPtr: &LocalTeacher{},
+		},
+		{
+			name:   "WithCaseSensitiveNames still matches exact names",
+			copier: New(WithCaseSensitiveNames()),
+			input: &Thing{
+				A: "a",
+			},
+			outputPtr: &Thing2{},
+			expectedRespPtr: &Thing2{
+				A: &[]string{"a"}[0],
+			},
+		},
+		{
+			name:   "WithTimeLocation converts timestamps into location",
+			copier: New(WithTimeLocation(denver)),
+			input: OptionsTimestamp{
+				Time: timestamppb.New(time1),
+			},
+			outputPtr: &OptionsTime{},
+			expectedRespPtr: &OptionsTime{
+				Time: &time1Denver,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.copier.Copy(tc.input, tc.outputPtr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRespPtr, tc.outputPtr)
+		})
+	}
+}
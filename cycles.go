@@ -0,0 +1,53 @@
+package deepcopy
+
+import (
+	"reflect"
+)
+
+// visitKey identifies a source value that has already been copied into a
+// destination pointer of a given type.
+type visitKey struct {
+	addr    uintptr
+	srcType reflect.Type
+	dstType reflect.Type
+}
+
+// visit records the destination pointer created for a source value.
+// done is false while the value is still being copied, so encountering
+// it again means the source contains a reference cycle.
+type visit struct {
+	ptr  reflect.Value
+	done bool
+}
+
+// visitKeyFor returns the key under which inValue is tracked when copied
+// into a destination of type outType. Only addressable source values can
+// be tracked.
+func visitKeyFor(inValue reflect.Value, outType reflect.Type) (visitKey, bool) {
+	if !inValue.CanAddr() || inValue.Type().Size() == 0 {
+		// zero-sized values may share an address without being the same value
+		return visitKey{}, false
+	}
+	return visitKey{
+		addr:    inValue.Addr().Pointer(),
+		srcType: inValue.Type(),
+		dstType: outType,
+	}, true
+}
+
+// lookupVisit returns the destination pointer already created for key.
+func (s *copyState) lookupVisit(key visitKey) (*visit, bool) {
+	v, ok := s.visited[key]
+	return v, ok
+}
+
+// startVisit records ptr as the destination for key before its value is
+// copied, so that references back to the source value resolve to ptr.
+func (s *copyState) startVisit(key visitKey, ptr reflect.Value) *visit {
+	if s.visited == nil {
+		s.visited = make(map[visitKey]*visit)
+	}
+	v := &visit{ptr: ptr}
+	s.visited[key] = v
+	return v
+}
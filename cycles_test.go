@@ -0,0 +1,91 @@
+package deepcopy
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type LocalTripLeg struct {
+	Name string
+	Next *LocalTripLeg
+	Trip *LocalTrip
+}
+
+type LocalTrip struct {
+	Name  string
+	First *LocalTripLeg
+}
+
+type PbTripLeg struct {
+	Name string
+	Next *PbTripLeg
+	Trip *PbTrip
+}
+
+type PbTrip struct {
+	Name  string
+	First *PbTripLeg
+}
+
+type LocalRoute struct {
+	Start *LocalTeacher
+	End   *LocalTeacher
+}
+
+type PbRoute struct {
+	Start *PbTeacher
+	End   *PbTeacher
+}
+
+func TestCycles(t *testing.T) {
+	trip := &LocalTrip{Name: "loop"}
+	legA := &LocalTripLeg{Name: "a", Trip: trip}
+	legB := &LocalTripLeg{Name: "b", Trip: trip, Next: legA}
+	legA.Next = legB
+	trip.First = legA
+
+	out := PbTrip{}
+	err := DeepCopy(trip, &out)
+	require.NoError(t, err)
+	assert.Equal(t, "loop", out.Name)
+	assert.Equal(t, "a", out.First.Name)
+	assert.Equal(t, "b", out.First.Next.Name)
+	assert.Same(t, out.First, out.First.Next.Next)
+	assert.Same(t, &out, out.First.Trip)
+	assert.Same(t, &out, out.First.Next.Trip)
+}
+
+func TestSharedPointers(t *testing.T) {
+	teacher := &LocalTeacher{TeacherID: 4}
+	out := PbRoute{}
+	err := DeepCopy(LocalRoute{Start: teacher, End: teacher}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), out.Start.TeacherId)
+	assert.Same(t, out.Start, out.End)
+
+	identical := LocalRoute{}
+	err = DeepCopy(LocalRoute{Start: teacher, End: teacher}, &identical)
+	require.NoError(t, err)
+	assert.NotSame(t, teacher, identical.Start)
+	assert.Same(t, identical.Start, identical.End)
+}
+
+func TestCycleError(t *testing.T) {
+	copier := New(WithCycleError())
+	teacher := &LocalTeacher{TeacherID: 4}
+	out := PbRoute{}
+	err := copier.Copy(LocalRoute{Start: teacher, End: teacher}, &out)
+	require.NoError(t, err, "shared pointers are not cycles")
+	assert.Same(t, out.Start, out.End)
+
+	leg := &LocalTripLeg{Name: "a"}
+	leg.Next = leg
+	err = copier.Copy(leg, &PbTripLeg{})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrCycle))
+	var convErr *ConversionError
+	require.True(t, errors.As(err, &convErr))
+	assert.Equal(t, "Next", convErr.Path)
+}
@@ -8,24 +8,12 @@ import (
 	"strconv"
 	"strings"
 	"time"
-	"unicode"
-	"unicode/utf8"
 )
 
+// DeepCopy recursively copies input into output, which must be a pointer.
+// It uses a Copier with the default options; see New for other policies.
 func DeepCopy(input, output interface{}) error {
-	inputVal := reflect.ValueOf(input)
-	outputVal := reflect.ValueOf(output)
-	if outputVal.Kind() != reflect.Ptr {
-		errOutValueNotPtr := fmt.Errorf("expected pointer for arg1 %s but received %s", outputVal, outputVal.Kind())
-		return errOutValueNotPtr
-	}
-	outputVal = outputVal.Elem()
-	inputVal = smartMaxDereference(inputVal, outputVal)
-	err := smartCopy(inputVal, outputVal)
-	if err != nil {
-		return err
-	}
-	return nil
+	return defaultCopier.Copy(input, output)
 }
 
 const (
@@ -36,38 +24,94 @@ var (
 	timeType           = reflect.TypeOf(time.Time{})
 	timePtrType        = reflect.TypeOf(&time.Time{})
 	timestamppbPtrType = reflect.TypeOf(&timestamppb.Timestamp{})
+
+	defaultCopier = New()
 )
 
-func smartCopy(inValue reflect.Value, outValue reflect.Value) (err error) {
-	errCouldNotConvert := fmt.Errorf("unable to convert %s (type %s) to type %s", inValue.Interface(), inValue.Type(), outValue.Type())
+func (s *copyState) smartCopy(path string, inValue reflect.Value, outValue reflect.Value) error {
+	if !inValue.IsValid() || !outValue.CanAddr() {
+		return s.copyValue(path, inValue, outValue)
+	}
+	hooks := hooksFor(outValue.Type())
+	if hooks == 0 {
+		return s.copyValue(path, inValue, outValue)
+	}
+	return s.copyWithHooks(path, inValue, outValue, hooks)
+}
+
+func (s *copyState) copyValue(path string, inValue reflect.Value, outValue reflect.Value) (err error) {
 	if !outValue.CanSet() {
-		err := fmt.Errorf("value of %s cannot be set", outValue.Interface())
-		return err
+		return newConversionError(path, inValue, outValue.Type(), errors.New("destination cannot be set"))
 	}
 	done := false
 
+	// nil pointers leave the destination untouched, unless zero values are copied
+	if !inValue.IsValid() {
+		if s.zeroPolicy == ZeroCopy {
+			outValue.Set(reflect.Zero(outValue.Type()))
+		}
+		return
+	}
+
+	// handle registered converters
+	if conv, convInValue, ok := s.lookupConverter(inValue, outValue.Type()); ok {
+		return s.convertWithConverter(path, conv, convInValue, outValue)
+	}
+
+	// handle types that copy themselves
+	if inValue.Type() == outValue.Type() {
+		if method := copyMethodFor(inValue.Type()); method != nil && !copyMethodRunning(inValue) {
+			copyWithMethod(method, inValue, outValue)
+			return
+		}
+	}
+
 	// handle string -> number
 	if inValue.Kind() == reflect.String {
-		attempted, noError := parseStringFlexibly(inValue, outValue)
-		if attempted {
-			if !noError {
-				return errCouldNotConvert
+		if attempted, err := s.parseStringFlexibly(inValue, outValue); attempted {
+			if err != nil {
+				return newConversionError(path, inValue, outValue.Type(), err)
 			}
+			return nil
+		}
+	}
+
+	// handle time.Duration and *durationpb.Duration
+	if handled, err := s.convertDuration(inValue, outValue); handled {
+		if err != nil {
+			return newConversionError(path, inValue, outValue.Type(), err)
+		}
+		return nil
+	}
+
+	// handle number -> string
+	if outValue.Kind() == reflect.String && inValue.Kind() != reflect.String {
+		if str, ok := s.formatString(inValue); ok {
+			outValue.SetString(str)
 			return
 		}
 	}
 
-	// handle *timestamppb.Timestamp
-	if inValue.Type() == timestamppbPtrType {
-		err = convertFromTimestampPbPointer(inValue, outValue)
+	// handle *timestamppb.Timestamp, allocating other pointers first
+	if inValue.Type() == timestamppbPtrType && (outValue.Kind() != reflect.Ptr || outValue.Type() == timestamppbPtrType) {
+		err = s.convertFromTimestampPbPointer(inValue, outValue)
 		if err != nil {
-			return err
+			return newConversionError(path, inValue, outValue.Type(), err)
 		}
 		return
 	} else if outValue.Type() == timestamppbPtrType {
 		err = convertToTimestampPbPointer(inValue, outValue)
 		if err != nil {
-			return err
+			return newConversionError(path, inValue, outValue.Type(), err)
+		}
+		return
+	}
+
+	// handle number -> number
+	if isNumber(inValue.Kind()) && isNumber(outValue.Kind()) {
+		err = s.convertNumber(inValue, outValue)
+		if err != nil {
+			return newConversionError(path, inValue, outValue.Type(), err)
 		}
 		return
 	}
@@ -75,137 +119,264 @@ func smartCopy(inValue reflect.Value, outValue reflect.Value) (err error) {
 	switch outValue.Kind() {
 	default:
 		if inValue.Type() != outValue.Type() && !CanConvert(inValue, outValue.Type()) {
-			return errCouldNotConvert
+			return newConversionError(path, inValue, outValue.Type(), ErrUnconvertible)
 		} else {
 			newInValue := inValue.Convert(outValue.Type())
 			outValue.Set(newInValue)
 			done = true
 		}
-	case reflect.Array, reflect.Interface, reflect.Func, reflect.Map:
+	case reflect.Func:
 		outValue.Set(inValue)
 		done = true
-	case reflect.Slice:
-		if inValue.Kind() != reflect.Slice {
-			return errCouldNotConvert
-		}
-		sliceType := reflect.TypeOf(outValue.Interface())
-		newOutValue := reflect.MakeSlice(sliceType, inValue.Len(), inValue.Len())
-		for i := 0; i < inValue.Len(); i++ {
-			inVal := inValue.Index(i)
-			outVal := reflect.New(sliceType.Elem()).Elem()
-			inVal = smartMaxDereference(inVal, outVal)
-			err := smartCopy(inVal, outVal)
+	case reflect.Interface:
+		concreteValue := inValue
+		if concreteValue.Kind() == reflect.Interface {
+			concreteValue = concreteValue.Elem()
+		}
+		if !concreteValue.IsValid() {
+			outValue.Set(reflect.Zero(outValue.Type()))
+			return
+		}
+		if !concreteValue.Type().Implements(outValue.Type()) {
+			cause := fmt.Errorf("type %s does not implement %s", concreteValue.Type(), outValue.Type())
+			return newConversionError(path, inValue, outValue.Type(), cause)
+		}
+		if isOpaque(concreteValue.Type()) {
+			outValue.Set(concreteValue)
+			return
+		}
+		newOutValue := reflect.New(concreteValue.Type()).Elem()
+		err = s.smartCopy(path, smartMaxDereference(concreteValue, newOutValue), newOutValue)
+		if err != nil {
+			return err
+		}
+		outValue.Set(newOutValue)
+		done = true
+	case reflect.Map:
+		if inValue.Kind() != reflect.Map {
+			return newConversionError(path, inValue, outValue.Type(), ErrUnconvertible)
+		}
+		if inValue.IsNil() {
+			outValue.Set(reflect.Zero(outValue.Type()))
+			return
+		}
+		mapType := outValue.Type()
+		newOutValue := reflect.MakeMapWithSize(mapType, inValue.Len())
+		iter := inValue.MapRange()
+		for iter.Next() {
+			elemPath := keyPath(path, iter.Key())
+			outKey := reflect.New(mapType.Key()).Elem()
+			err := s.smartCopy(elemPath, smartMaxDereference(iter.Key(), outKey), outKey)
 			if err != nil {
-				return err
+				if err = s.handleError(err); err != nil {
+					return err
+				}
+				continue
 			}
-			newOutValue.Index(i).Set(outVal)
+			outElem := reflect.New(mapType.Elem()).Elem()
+			err = s.smartCopy(elemPath, smartMaxDereference(iter.Value(), outElem), outElem)
+			if err != nil {
+				if err = s.handleError(err); err != nil {
+					return err
+				}
+				continue
+			}
+			newOutValue.SetMapIndex(outKey, outElem)
 		}
 		outValue.Set(newOutValue)
 		done = true
-	case reflect.Ptr:
-		outValueInterfaceTypeOfElem := reflect.TypeOf(outValue.Interface()).Elem()
-		childOutVal := reflect.New(reflect.TypeOf(inValue.Interface()))
-		err := smartCopy(inValue, childOutVal.Elem())
+	case reflect.Slice:
+		if inValue.Kind() != reflect.Slice && inValue.Kind() != reflect.Array {
+			return newConversionError(path, inValue, outValue.Type(), ErrUnconvertible)
+		}
+		newOutValue := reflect.MakeSlice(outValue.Type(), inValue.Len(), inValue.Len())
+		err = s.copyElements(path, inValue, newOutValue, inValue.Len())
+		if err != nil {
+			return err
+		}
+		outValue.Set(newOutValue)
+		done = true
+	case reflect.Array:
+		if inValue.Kind() != reflect.Slice && inValue.Kind() != reflect.Array {
+			return newConversionError(path, inValue, outValue.Type(), ErrUnconvertible)
+		}
+		n := outValue.Len()
+		if inValue.Len() > n && s.lengthPolicy&LengthTruncate == 0 ||
+			inValue.Len() < n && s.lengthPolicy&LengthZeroPad == 0 {
+			cause := fmt.Errorf("length %d does not match length %d", inValue.Len(), n)
+			return newConversionError(path, inValue, outValue.Type(), cause)
+		}
+		if inValue.Len() < n {
+			n = inValue.Len()
+		}
+		newOutValue := reflect.New(outValue.Type()).Elem()
+		err = s.copyElements(path, inValue, newOutValue, n)
 		if err != nil {
 			return err
 		}
-		childOutValOut := reflect.New(outValueInterfaceTypeOfElem)
-		childOutValElemNonPtr := smartMaxDereference(childOutVal.Elem(), childOutValOut.Elem())
-		err = smartCopy(childOutValElemNonPtr, childOutValOut.Elem())
+		outValue.Set(newOutValue)
+		done = true
+	case reflect.Ptr:
+		key, tracked := visitKeyFor(inValue, outValue.Type())
+		if tracked {
+			if v, ok := s.lookupVisit(key); ok {
+				if !v.done && s.cycleError {
+					return newConversionError(path, inValue, outValue.Type(), ErrCycle)
+				}
+				// reuse the pointer already created for this source value
+				outValue.Set(v.ptr)
+				return
+			}
+		}
+		newOutValue := reflect.New(outValue.Type().Elem())
+		var v *visit
+		if tracked {
+			v = s.startVisit(key, newOutValue)
+		}
+		childInValue := smartMaxDereference(inValue, newOutValue.Elem())
+		err := s.smartCopy(path, childInValue, newOutValue.Elem())
 		if err != nil {
 			return err
 		}
-		outValuePtr := reflect.New(reflect.TypeOf(childOutValOut.Elem().Interface()))
-		outValuePtr.Elem().Set(reflect.ValueOf(childOutValOut.Elem().Interface()))
-		outValue.Set(outValuePtr)
+		if v != nil {
+			v.done = true
+		}
+		outValue.Set(newOutValue)
 		done = true
 	case reflect.Struct:
-		startingCount := 0
 		if outValue.Type() == timeType {
-			err = convertToTime(inValue, outValue)
+			err = s.convertToTime(inValue, outValue)
 			if err != nil {
-				return err
+				return newConversionError(path, inValue, outValue.Type(), err)
 			}
-			startingCount = inValue.NumField()
+			return
 		} else if inValue.Kind() != reflect.Struct {
-			return errCouldNotConvert
+			return newConversionError(path, inValue, outValue.Type(), ErrUnconvertible)
 		}
 
-		inValNumField := inValue.NumField()
-		for i := startingCount; i < inValNumField; i++ {
-			foundMatchingOutputField := false
-			inputField := inValue.Field(i)
-			inputFieldName := inValue.Type().Field(i).Name
-			if !inputField.CanInterface() {
-				// skip unexported fields
-				continue
-			}
-
-			inputFieldInterface := inputField.Interface()
-			if reflect.DeepEqual(inputFieldInterface, reflect.Zero(reflect.TypeOf(inputFieldInterface)).Interface()) {
+		plan := s.structPlan(inValue.Type(), outValue.Type())
+		if s.strictMode != StrictOff {
+			s.recordUnmatched(path, inValue.Type(), outValue.Type(), plan)
+		}
+		for _, fp := range plan.fields {
+			inputField := fieldByIndex(inValue, fp.in)
+			if !fp.copyZero && inputField.IsZero() {
 				// skip null fields
 				continue
 			}
-			for j := 0; j < outValue.NumField(); j++ {
-				if foundMatchingOutputField {
-					continue
+			outputField := settableFieldByIndex(outValue, fp.out)
+			inputFieldPath := fieldPath(path, fp.name)
+			if fp.conv != nil {
+				err = s.convertWithConverter(inputFieldPath, fp.conv, inputField, outputField)
+			} else if inputField = smartMaxDereference(inputField, outputField); !inputField.IsValid() {
+				// nil pointers clear the destination
+				outputField.Set(reflect.Zero(outputField.Type()))
+			} else {
+				err = s.smartCopy(inputFieldPath, inputField, outputField)
+			}
+			if err != nil {
+				if err = s.handleError(err); err != nil {
+					return err
 				}
-				outputField := outValue.Field(j)
-				outputFieldName := outValue.Type().Field(j).Name
-				rune0, _ := utf8.DecodeRuneInString(outputFieldName)
-				if unicode.IsLower(rune0) {
-					// skip unexported fields
-					continue
+			}
+		}
+		for _, field := range plan.required {
+			if fieldByIndex(outValue, field.index).IsZero() {
+				err = &RequiredFieldError{
+					Path: fieldPath(path, field.name),
+					Type: outValue.Type().FieldByIndex(field.index).Type,
 				}
-
-				if fieldsMatch(reflect.TypeOf(inValue.Interface()).Field(i), reflect.TypeOf(outValue.Interface()).Field(j)) {
-					if !inputField.IsValid() {
-						err = errors.New(errCouldNotConvert.Error() + fmt.Sprintf(": field %s is invalid", inputFieldName))
-						return err
-					}
-					if !outputField.CanSet() {
-						err = errors.New(errCouldNotConvert.Error() + fmt.Sprintf(": cannot set field %s", outputFieldName))
-						return err
-					}
-					inputField = smartMaxDereference(inputField, outputField)
-					err = smartCopy(inputField, outputField)
-					if err != nil {
-						return err
-					}
-					foundMatchingOutputField = true
+				if err = s.handleError(err); err != nil {
+					return err
 				}
 			}
 		}
 		done = true
 	}
 	if !done {
-		return errCouldNotConvert
+		return newConversionError(path, inValue, outValue.Type(), ErrUnconvertible)
 	}
 	return
 }
 
+// copyElements copies the first n elements of inValue into outValue, which
+// must be a settable slice or array.
+func (s *copyState) copyElements(path string, inValue, outValue reflect.Value, n int) error {
+	for i := 0; i < n; i++ {
+		outElem := outValue.Index(i)
+		inElem := smartMaxDereference(inValue.Index(i), outElem)
+		err := s.smartCopy(indexPath(path, i), inElem, outElem)
+		if err != nil {
+			if err = s.handleError(err); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isOpaque reports whether values of type t, after dereferencing, are
+// structs with no exported fields and no copy method (such as the errors
+// created by errors.New). DeepCopy cannot copy their contents, so they are
+// shared.
+func isOpaque(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == timeType || copyMethodFor(t) != nil {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return false
+		}
+	}
+	return true
+}
+
 func smartMaxDereference(input, output reflect.Value) reflect.Value {
-	if input.Type() == timestamppbPtrType {
-		if output.Type() != timeType {
+	if output.Kind() == reflect.Interface {
+		// values copied into interfaces keep their concrete type
+		return input
+	}
+	return dereferenceInput(input, output)
+}
+
+// dereferenceInput dereferences the input of a copy into output, including
+// pointers copied into interfaces.
+func dereferenceInput(input, output reflect.Value) reflect.Value {
+	for input.Kind() == reflect.Interface {
+		input = input.Elem()
+	}
+	if !input.IsValid() {
+		return input
+	}
+	// protobuf well-known types are converted from their pointers at any
+	// depth, unless the output is the message struct itself
+	for input.Kind() == reflect.Ptr && !input.IsNil() {
+		if isWellKnownPtr(input.Type()) && output.Type() != input.Type().Elem() {
 			return input
 		}
-		return maxDereference(input)
+		input = input.Elem()
 	}
 	return maxDereference(input)
 }
 
+func isWellKnownPtr(t reflect.Type) bool {
+	return t == timestamppbPtrType || t == durationpbPtrType
+}
+
 func maxDereference(value reflect.Value) reflect.Value {
-	if value.Kind() != reflect.Ptr {
+	if value.Kind() != reflect.Ptr && value.Kind() != reflect.Interface {
 		return value
 	}
 	return maxDereference(value.Elem())
 }
 
-func convertToTime(inValue, outValue reflect.Value) error {
+func (c *Copier) convertToTime(inValue, outValue reflect.Value) error {
 	// remember: inValue will never be ptr
-	errCouldNotConvert := fmt.Errorf("unable to convert %s (type %s) to type %s", inValue.Interface(), inValue.Type(), outValue.Type())
 	if outValue.Type() != timeType {
-		return errCouldNotConvert
+		return ErrUnconvertible
 	}
 	switch inValue.Type() {
 	case timeType:
@@ -216,49 +387,55 @@ func convertToTime(inValue, outValue reflect.Value) error {
 		outValue.Set(newOutVal.Elem())
 	case timestamppbPtrType:
 		inTimePreConvert := inValue.Interface().(*timestamppb.Timestamp)
-		inTime := inTimePreConvert.AsTime()
+		inTime := c.asTime(inTimePreConvert)
 		inTimeVal := reflect.ValueOf(inTime)
 		newOutVal := reflect.New(reflect.TypeOf(inTime))
 		newOutVal.Elem().Set(inTimeVal)
 		outValue.Set(newOutVal.Elem())
 	default:
-		return errCouldNotConvert
+		return ErrUnconvertible
 	}
 	return nil
 }
 
-func convertFromTimestampPbPointer(inValue, outValue reflect.Value) error {
-	errCouldNotConvert := fmt.Errorf("unable to convert %s (type %s) to type %s", inValue.Interface(), inValue.Type(), outValue.Type())
+func (c *Copier) asTime(ts *timestamppb.Timestamp) time.Time {
+	t := ts.AsTime()
+	if c.timeLocation != nil {
+		t = t.In(c.timeLocation)
+	}
+	return t
+}
+
+func (c *Copier) convertFromTimestampPbPointer(inValue, outValue reflect.Value) error {
 	if inValue.Type() != timestamppbPtrType {
-		return errCouldNotConvert
+		return ErrUnconvertible
 	}
 	switch outValue.Type() {
 	case timestamppbPtrType:
 		outValue.Set(inValue)
 	case timeType:
 		inTimePreConvert := inValue.Interface().(*timestamppb.Timestamp)
-		inTime := inTimePreConvert.AsTime()
+		inTime := c.asTime(inTimePreConvert)
 		inTimeVal := reflect.ValueOf(inTime)
 		newOutVal := reflect.New(reflect.TypeOf(inTime))
 		newOutVal.Elem().Set(inTimeVal)
 		outValue.Set(newOutVal.Elem())
 	case timePtrType:
 		inTimePreConvert := inValue.Interface().(*timestamppb.Timestamp)
-		inTime := inTimePreConvert.AsTime()
+		inTime := c.asTime(inTimePreConvert)
 		inTimeVal := reflect.ValueOf(&inTime)
 		newOutVal := reflect.New(reflect.TypeOf(&inTime))
 		newOutVal.Elem().Set(inTimeVal)
 		outValue.Set(newOutVal.Elem())
 	default:
-		return errCouldNotConvert
+		return ErrUnconvertible
 	}
 	return nil
 }
 
 func convertToTimestampPbPointer(inValue, outValue reflect.Value) error {
-	errCouldNotConvert := fmt.Errorf("unable to convert %s (type %s) to type %s", inValue.Interface(), inValue.Type(), outValue.Type())
 	if outValue.Type() != timestamppbPtrType {
-		return errCouldNotConvert
+		return ErrUnconvertible
 	}
 	switch inValue.Type() {
 	case timeType:
@@ -269,28 +446,51 @@ func convertToTimestampPbPointer(inValue, outValue reflect.Value) error {
 	case timestamppbPtrType:
 		outValue.Set(inValue)
 	default:
-		return errCouldNotConvert
+		return ErrUnconvertible
 	}
 	return nil
 }
 
-func fieldsMatch(inField, outField reflect.StructField) bool {
+func (c *Copier) fieldsMatch(inField, outField reflect.StructField) bool {
 
-	inFieldName := strings.ToLower(inField.Name)
-	outFieldName := strings.ToLower(outField.Name)
+	inFieldName := c.normalizeName(inField.Name)
+	outFieldName := c.normalizeName(outField.Name)
 	if inFieldName == "" || outFieldName == "" {
 		return false
 	}
-	inFieldTag := strings.ToLower(inField.Tag.Get(DC_STRUCT_TAG))
-	outFieldTag := strings.ToLower(outField.Tag.Get(DC_STRUCT_TAG))
+	inFieldTag := c.normalizeName(c.parseTag(inField).name)
+	outFieldTag := c.normalizeName(c.parseTag(outField).name)
 
 	if inFieldName == outFieldName || inFieldName == outFieldTag || outFieldName == inFieldTag {
 		return true
 	}
+	if inFieldTag != "" && inFieldTag == outFieldTag {
+		return true
+	}
 
 	return false
 }
 
+// fieldNameMatches reports whether name selects field, by its name or by
+// its tag name.
+func (c *Copier) fieldNameMatches(field reflect.StructField, name string) bool {
+	name = c.normalizeName(name)
+	if name == "" {
+		return false
+	}
+	return name == c.normalizeName(field.Name) || name == c.normalizeName(c.parseTag(field).name)
+}
+
+func (c *Copier) normalizeName(name string) string {
+	for _, strategy := range c.naming {
+		name = strategy(name)
+	}
+	if c.caseSensitive {
+		return name
+	}
+	return strings.ToLower(name)
+}
+
 // taken from reflect in go@1.17:
 func CanConvert(v reflect.Value, t reflect.Type) bool {
 	vt := v.Type()
@@ -310,106 +510,21 @@ func CanConvert(v reflect.Value, t reflect.Type) bool {
 	return true
 }
 
-// TODO: test for converting string to every one of these types
-func parseStringFlexibly(inValue, outValue reflect.Value) (didAttempt bool, worked bool) {
-	// bool #1 represents "Did we try to convert?"
-	didAttempt = true
-	// bool #2 represents whether conversion worked
-	worked = true
-	// if bool #1 is false, then bool #2 is ignored
-	if inValue.Kind() != reflect.String {
-		return false, true
-	}
-	s := strings.ToLower(inValue.String())
-	switch outValue.Kind() {
-	default:
-		didAttempt = false
+// formatString formats booleans and numbers as strings, and reports
+// whether inValue is of such a kind.
+func (c *Copier) formatString(inValue reflect.Value) (string, bool) {
+	switch inValue.Kind() {
 	case reflect.Bool:
-		if s == "t" || s == "true" {
-			outValue.SetBool(true)
-		} else if s == "f" || s == "false" {
-			outValue.SetBool(false)
-		} else {
-			worked = false
-		}
-	case reflect.Int, reflect.Int64:
-		parsedString, err := strconv.ParseInt(s, 10, 64)
-		if err != nil {
-			return true, false
-		}
-		val := reflect.ValueOf(parsedString)
-		outValue.Set(val)
-	case reflect.Int8:
-		parsedString, err := strconv.ParseInt(s, 10, 8)
-		if err != nil {
-			return true, false
+		if inValue.Bool() {
+			return c.trueString, true
 		}
-		num := int8(parsedString)
-		val := reflect.ValueOf(num)
-		outValue.Set(val)
-	case reflect.Int16:
-		parsedString, err := strconv.ParseInt(s, 10, 16)
-		if err != nil {
-			return true, false
-		}
-		num := int16(parsedString)
-		val := reflect.ValueOf(num)
-		outValue.Set(val)
-	case reflect.Int32:
-		parsedString, err := strconv.ParseInt(s, 10, 32)
-		if err != nil {
-			return true, false
-		}
-		num := int32(parsedString)
-		val := reflect.ValueOf(num)
-		outValue.Set(val)
-	case reflect.Uint, reflect.Uint64:
-		parsedString, err := strconv.ParseUint(s, 10, 64)
-		if err != nil {
-			return true, false
-		}
-		val := reflect.ValueOf(parsedString)
-		outValue.Set(val)
-	case reflect.Uint8:
-		parsedString, err := strconv.ParseUint(s, 10, 8)
-		if err != nil {
-			return true, false
-		}
-		num := uint8(parsedString)
-		val := reflect.ValueOf(num)
-		outValue.Set(val)
-	case reflect.Uint16:
-		parsedString, err := strconv.ParseUint(s, 10, 16)
-		if err != nil {
-			return true, false
-		}
-		num := uint16(parsedString)
-		val := reflect.ValueOf(num)
-		outValue.Set(val)
-	case reflect.Uint32:
-		parsedString, err := strconv.ParseUint(s, 10, 32)
-		if err != nil {
-			return true, false
-		}
-		num := uint32(parsedString)
-		val := reflect.ValueOf(num)
-		outValue.Set(val)
-	case reflect.Float64:
-		parsedString, err := strconv.ParseFloat(s, 64)
-		if err != nil {
-			return true, false
-		}
-		val := reflect.ValueOf(parsedString)
-		outValue.Set(val)
-	case reflect.Float32:
-		parsedString, err := strconv.ParseFloat(s, 64)
-		if err != nil {
-			return true, false
-		}
-		num := float32(parsedString)
-		val := reflect.ValueOf(num)
-		outValue.Set(val)
+		return c.falseString, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(inValue.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(inValue.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(inValue.Float(), c.floatFormat, c.floatPrecision, inValue.Type().Bits()), true
 	}
-
-	return
+	return "", false
 }
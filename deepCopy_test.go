@@ -171,7 +171,7 @@ func TestDeepCopy(t *testing.T) {
 				BadConversion1: int32(12),
 			},
 			outputPtr:   &NewStruct2{},
-			expectedErr: errors.New("unable to convert %!s(int32=12) (type int32) to type timestamppb.Timestamp"),
+			expectedErr: errors.New("BadConversion1: unable to convert %!s(int32=12) (type int32) to type timestamppb.Timestamp"),
 		},
 		{
 			name: "input val has unexported field",
@@ -237,7 +237,7 @@ func TestDeepCopy(t *testing.T) {
 				Enum: LocalInspectionType_Pickup,
 			},
 			outputPtr:   &NewStruct2{},
-			expectedErr: errors.New("unable to convert pickup (type deepcopy.LocalInspectionType) to type deepcopy.InspectionType"),
+			expectedErr: errors.New("Enum: unable to convert pickup (type deepcopy.LocalInspectionType) to type deepcopy.InspectionType"),
 		},
 		{
 			name:        "non-pointer outputType, should fail",
@@ -572,3 +572,69 @@ func TestDeepCopyToTimestamp(t *testing.T) {
 		})
 	}
 }
+
+type LocalMeasurement struct {
+	ID       uint64
+	Offset   int8
+	Reading  float64
+	Ratio    float32
+	Verified bool
+}
+
+type StringMeasurement struct {
+	ID       string
+	Offset   string
+	Reading  string
+	Ratio    string
+	Verified string
+}
+
+func TestFormatString(t *testing.T) {
+	input := LocalMeasurement{ID: 4, Offset: -12, Reading: 1234.5, Ratio: 0.1, Verified: true}
+
+	testCases := []struct {
+		name            string
+		copier          *Copier
+		expectedRespPtr *StringMeasurement
+		roundTrips      bool
+	}{
+		{
+			name:       "default formatting",
+			copier:     New(),
+			roundTrips: true,
+			expectedRespPtr: &StringMeasurement{
+				ID:       "4",
+				Offset:   "-12",
+				Reading:  "1234.5",
+				Ratio:    "0.1",
+				Verified: "true",
+			},
+		},
+		{
+			name:   "float format and bool strings",
+			copier: New(WithFloatFormat('e', 2), WithBoolStrings("yes", "no")),
+			expectedRespPtr: &StringMeasurement{
+				ID:       "4",
+				Offset:   "-12",
+				Reading:  "1.23e+03",
+				Ratio:    "1.00e-01",
+				Verified: "yes",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &StringMeasurement{}
+			err := tc.copier.Copy(input, out)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRespPtr, out)
+
+			back := LocalMeasurement{}
+			err = tc.copier.Copy(out, &back)
+			require.NoError(t, err)
+			if tc.roundTrips {
+				assert.Equal(t, input, back)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package deepcopy
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	durationType      = reflect.TypeOf(time.Duration(0))
+	durationpbPtrType = reflect.TypeOf(&durationpb.Duration{})
+	int64Type         = reflect.TypeOf(int64(0))
+)
+
+// WithDurationUnit sets the unit of numbers copied into or from
+// time.Duration and *durationpb.Duration values, such as time.Second.
+// Defaults to time.Nanosecond.
+func WithDurationUnit(unit time.Duration) Option {
+	return func(c *Copier) {
+		c.durationUnit = unit
+	}
+}
+
+// convertDuration copies between *durationpb.Duration values and
+// durations, strings and numbers, and formats durations as strings. It
+// reports whether it handled the copy.
+func (c *Copier) convertDuration(inValue, outValue reflect.Value) (bool, error) {
+	inType, outType := inValue.Type(), outValue.Type()
+	if inType == outType || inType != durationpbPtrType && outType != durationpbPtrType &&
+		(inType != durationType || outValue.Kind() != reflect.String) {
+		return false, nil
+	}
+
+	var d time.Duration
+	switch {
+	case inType == durationpbPtrType:
+		pb := inValue.Interface().(*durationpb.Duration)
+		if err := pb.CheckValid(); err != nil {
+			return true, err
+		}
+		d = pb.AsDuration()
+	case inType == durationType:
+		d = time.Duration(inValue.Int())
+	case inValue.Kind() == reflect.String || isNumber(inValue.Kind()):
+		v := reflect.New(durationType).Elem()
+		var err error
+		if inValue.Kind() == reflect.String {
+			_, err = c.parseStringFlexibly(inValue, v)
+		} else {
+			err = c.toDuration(inValue, v)
+		}
+		if err != nil {
+			return true, err
+		}
+		d = time.Duration(v.Int())
+	default:
+		return false, nil
+	}
+
+	switch {
+	case outType == durationpbPtrType:
+		outValue.Set(reflect.ValueOf(durationpb.New(d)))
+	case outType == durationType:
+		outValue.SetInt(int64(d))
+	case outValue.Kind() == reflect.String:
+		if c.parseFormats&ParseDurations != 0 {
+			outValue.SetString(d.String())
+			return true, nil
+		}
+		v := reflect.New(int64Type).Elem()
+		if err := c.fromDuration(d, v); err != nil {
+			return true, err
+		}
+		outValue.SetString(strconv.FormatInt(v.Int(), 10))
+	case isNumber(outValue.Kind()):
+		return true, c.fromDuration(d, outValue)
+	default:
+		// pointers are allocated before the duration is copied into them
+		return false, nil
+	}
+	return true, nil
+}
+
+// toDuration copies the number inValue, counted in the Copier's duration
+// unit, into the duration outValue.
+func (c *Copier) toDuration(inValue, outValue reflect.Value) error {
+	unit := c.unit()
+	if isFloat(inValue.Kind()) {
+		return c.checkedConvert(reflect.ValueOf(inValue.Float()*float64(unit)), outValue)
+	}
+	v := reflect.New(int64Type).Elem()
+	if err := c.checkedConvert(inValue, v); err != nil {
+		return err
+	}
+	n := v.Int()
+	if c.overflowPolicy == OverflowAllow {
+		// wraps around like a Go multiplication
+		outValue.SetInt(n * int64(unit))
+		return nil
+	}
+	if n > math.MaxInt64/int64(unit) {
+		_, err := c.saturate(func() { outValue.SetInt(math.MaxInt64) })
+		return err
+	} else if n < math.MinInt64/int64(unit) {
+		_, err := c.saturate(func() { outValue.SetInt(math.MinInt64) })
+		return err
+	}
+	outValue.SetInt(n * int64(unit))
+	return nil
+}
+
+// fromDuration copies d into the number outValue, counted in the Copier's
+// duration unit. Durations that are not a whole number of units are
+// handled like floats with a fractional part.
+func (c *Copier) fromDuration(d time.Duration, outValue reflect.Value) error {
+	unit := c.unit()
+	if d%unit == 0 {
+		return c.checkedConvert(reflect.ValueOf(int64(d/unit)), outValue)
+	}
+	return c.checkedConvert(reflect.ValueOf(float64(d)/float64(unit)), outValue)
+}
+
+func (c *Copier) unit() time.Duration {
+	if c.durationUnit <= 0 {
+		return time.Nanosecond
+	}
+	return c.durationUnit
+}
@@ -0,0 +1,109 @@
+package deepcopy
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type LocalRental struct {
+	Length      time.Duration
+	GracePeriod *time.Duration
+	Extension   string
+	Buffer      int64
+}
+
+type PbRental struct {
+	Length      *durationpb.Duration
+	GracePeriod **durationpb.Duration
+	Extension   *durationpb.Duration
+	Buffer      *durationpb.Duration
+}
+
+func TestDurations(t *testing.T) {
+	ninetyMinutes := durationpb.New(90 * time.Minute)
+	ninety := 90 * time.Minute
+	hours := int64(1) << 40
+	testCases := []struct {
+		name        string
+		opts        []Option
+		input       interface{}
+		output      interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{name: "durationpb to duration", input: ninetyMinutes, output: new(time.Duration), expected: 90 * time.Minute},
+		{name: "durationpb to duration pointer", input: ninetyMinutes, output: new(*time.Duration), expected: &ninety},
+		{name: "durationpb pointer to duration", input: &ninetyMinutes, output: new(time.Duration), expected: 90 * time.Minute},
+		{name: "durationpb to durationpb", input: ninetyMinutes, output: new(*durationpb.Duration), expected: ninetyMinutes},
+		{name: "durationpb to string", input: ninetyMinutes, output: new(string), expected: "5400000000000"},
+		{name: "durationpb to formatted string", opts: []Option{WithParseFormats(ParseDurations)}, input: ninetyMinutes, output: new(string), expected: "1h30m0s"},
+		{name: "durationpb to nanoseconds", input: ninetyMinutes, output: new(int64), expected: int64(90 * time.Minute)},
+		{name: "durationpb to minutes", opts: []Option{WithDurationUnit(time.Minute)}, input: ninetyMinutes, output: new(int), expected: 90},
+		{name: "durationpb to fractional hours", opts: []Option{WithDurationUnit(time.Hour)}, input: ninetyMinutes, output: new(float64), expected: 1.5},
+		{name: "durationpb to whole hours", opts: []Option{WithDurationUnit(time.Hour)}, input: ninetyMinutes, output: new(int), expectedErr: ErrOverflow},
+		{name: "durationpb to truncated hours", opts: []Option{WithDurationUnit(time.Hour), WithOverflowPolicy(OverflowTruncate)}, input: ninetyMinutes, output: new(int), expected: 1},
+		{name: "invalid durationpb", input: &durationpb.Duration{Seconds: 1, Nanos: -1}, output: new(time.Duration), expectedErr: ErrUnconvertible},
+		{name: "duration to durationpb", input: 90 * time.Minute, output: new(*durationpb.Duration), expected: ninetyMinutes},
+		{name: "string to durationpb", opts: []Option{WithParseFormats(ParseDurations)}, input: "1h30m", output: new(*durationpb.Duration), expected: ninetyMinutes},
+		{name: "unparsed string to durationpb", input: "1h30m", output: new(*durationpb.Duration), expectedErr: ErrUnconvertible},
+		{name: "seconds string to durationpb", opts: []Option{WithDurationUnit(time.Second)}, input: "5400", output: new(*durationpb.Duration), expected: ninetyMinutes},
+		{name: "minutes to durationpb", opts: []Option{WithDurationUnit(time.Minute)}, input: uint8(90), output: new(*durationpb.Duration), expected: ninetyMinutes},
+		{name: "fractional hours to duration", opts: []Option{WithDurationUnit(time.Hour)}, input: 1.5, output: new(time.Duration), expected: 90 * time.Minute},
+		{name: "hours to duration overflow", opts: []Option{WithDurationUnit(time.Hour)}, input: hours, output: new(time.Duration), expectedErr: ErrOverflow},
+		{name: "hours to saturated duration", opts: []Option{WithDurationUnit(time.Hour), WithOverflowPolicy(OverflowSaturate)}, input: hours, output: new(time.Duration), expected: time.Duration(math.MaxInt64)},
+		{name: "hours to wrapped duration", opts: []Option{WithDurationUnit(time.Hour), WithOverflowPolicy(OverflowAllow)}, input: hours, output: new(time.Duration), expected: time.Duration(hours * int64(time.Hour))},
+		{name: "duration to seconds string", opts: []Option{WithDurationUnit(time.Second)}, input: 90 * time.Minute, output: new(string), expected: "5400"},
+		{name: "duration to duration", opts: []Option{WithDurationUnit(time.Second)}, input: 90 * time.Minute, output: new(time.Duration), expected: 90 * time.Minute},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := New(tc.opts...).Copy(tc.input, tc.output)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, reflect.ValueOf(tc.output).Elem().Interface())
+		})
+	}
+}
+
+func TestDurationsStruct(t *testing.T) {
+	grace := 15 * time.Minute
+	input := LocalRental{Length: 2 * time.Hour, GracePeriod: &grace, Extension: "30m", Buffer: 300}
+	var output PbRental
+	copier := New(WithParseFormats(ParseDurations), WithDurationUnit(time.Second))
+	err := copier.Copy(input, &output)
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, output.Length.AsDuration())
+	require.NotNil(t, output.GracePeriod)
+	assert.Equal(t, grace, (*output.GracePeriod).AsDuration())
+	assert.Equal(t, 30*time.Minute, output.Extension.AsDuration())
+	assert.Equal(t, 5*time.Minute, output.Buffer.AsDuration())
+
+	var roundTrip LocalRental
+	err = copier.Copy(output, &roundTrip)
+	require.NoError(t, err)
+	assert.Equal(t, LocalRental{Length: 2 * time.Hour, GracePeriod: &grace, Extension: "30m0s", Buffer: 300}, roundTrip)
+}
+
+func TestTimestampToTime(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	ts := timestamppb.New(now)
+
+	var out time.Time
+	err := DeepCopy(ts, &out)
+	require.NoError(t, err)
+	assert.Equal(t, now, out)
+
+	var outPtr **time.Time
+	err = DeepCopy(&ts, &outPtr)
+	require.NoError(t, err)
+	assert.Equal(t, now, **outPtr)
+}
@@ -0,0 +1,153 @@
+package deepcopy
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type Base struct {
+	ID        uint64
+	CreatedAt time.Time
+	Name      string
+}
+
+type UserModel struct {
+	Base
+	Name string
+}
+
+type UserPtrModel struct {
+	*Base
+	Email string
+}
+
+type UserDTO struct {
+	ID        uint64
+	CreatedAt time.Time
+	Name      string
+	Email     string
+}
+
+type AuditedUserDTO struct {
+	Base  BaseDTO
+	Email string
+}
+
+type BaseDTO struct {
+	ID uint64
+}
+
+type TaggedUserModel struct {
+	Base  `dc:"audit"`
+	Email string
+}
+
+type auditFields struct {
+	UpdatedBy string
+}
+
+type InternalUserModel struct {
+	auditFields
+	Email string
+}
+
+func TestEmbeddedStructs(t *testing.T) {
+	createdAt := time.Date(2022, 8, 17, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name            string
+		input           interface{}
+		outputPtr       interface{}
+		expectedRespPtr interface{}
+	}{
+		{
+			name: "promoted fields to flat struct",
+			input: UserModel{
+				Base: Base{ID: 7, CreatedAt: createdAt, Name: "shadowed"},
+				Name: "Jane",
+			},
+			outputPtr: &UserDTO{},
+			expectedRespPtr: &UserDTO{
+				ID:        7,
+				CreatedAt: createdAt,
+				Name:      "Jane",
+			},
+		},
+		{
+			name: "flat struct to promoted fields",
+			input: UserDTO{
+				ID:        7,
+				CreatedAt: createdAt,
+				Name:      "Jane",
+			},
+			outputPtr: &UserModel{},
+			expectedRespPtr: &UserModel{
+				Base: Base{ID: 7, CreatedAt: createdAt},
+				Name: "Jane",
+			},
+		},
+		{
+			name:      "nil embedded pointer is allocated",
+			input:     UserDTO{ID: 7, Email: "jane@example.com"},
+			outputPtr: &UserPtrModel{},
+			expectedRespPtr: &UserPtrModel{
+				Base:  &Base{ID: 7},
+				Email: "jane@example.com",
+			},
+		},
+		{
+			name:            "embedded pointer is not allocated for zero values",
+			input:           UserDTO{Email: "jane@example.com"},
+			outputPtr:       &UserPtrModel{},
+			expectedRespPtr: &UserPtrModel{Email: "jane@example.com"},
+		},
+		{
+			name:            "nil embedded pointer in source",
+			input:           UserPtrModel{Email: "jane@example.com"},
+			outputPtr:       &UserDTO{ID: 7},
+			expectedRespPtr: &UserDTO{ID: 7, Email: "jane@example.com"},
+		},
+		{
+			name: "embedded struct matched as a whole",
+			input: UserPtrModel{
+				Base:  &Base{ID: 7, Name: "Jane"},
+				Email: "jane@example.com",
+			},
+			outputPtr: &AuditedUserDTO{},
+			expectedRespPtr: &AuditedUserDTO{
+				Base:  BaseDTO{ID: 7},
+				Email: "jane@example.com",
+			},
+		},
+		{
+			name: "tagged embedded struct is not flattened",
+			input: TaggedUserModel{
+				Base:  Base{ID: 7},
+				Email: "jane@example.com",
+			},
+			outputPtr:       &UserDTO{},
+			expectedRespPtr: &UserDTO{Email: "jane@example.com"},
+		},
+		{
+			name: "unexported embedded struct",
+			input: struct {
+				UpdatedBy string
+				Email     string
+			}{UpdatedBy: "admin", Email: "jane@example.com"},
+			outputPtr: &InternalUserModel{},
+			expectedRespPtr: &InternalUserModel{
+				auditFields: auditFields{UpdatedBy: "admin"},
+				Email:       "jane@example.com",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := DeepCopy(tc.input, tc.outputPtr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRespPtr, tc.outputPtr)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package deepcopy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrNotPointer is returned when the output argument is not a pointer,
+	// or is a nil pointer.
+	ErrNotPointer = errors.New("expected pointer")
+	// ErrUnconvertible is matched by every *ConversionError, and is the
+	// cause of conversions between incompatible types.
+	ErrUnconvertible = errors.New("unable to convert")
+	// ErrOverflow is the cause of numeric conversions whose value does not
+	// fit in the destination type, or would lose precision in it (see
+	// OverflowPolicy).
+	ErrOverflow = errors.New("value out of range")
+	// ErrCycle is the cause of copies that encounter a reference cycle when
+	// cycles are not allowed.
+	ErrCycle = errors.New("reference cycle detected")
+	// ErrRequired is matched by every *RequiredFieldError.
+	ErrRequired = errors.New("required field is zero")
+	// ErrUnmatchedField is matched by every *UnmatchedFieldsError.
+	ErrUnmatchedField = errors.New("unmatched field")
+
+	// errErrorLimit aborts a copy once WithCollectErrors' limit is reached.
+	errErrorLimit = errors.New("error limit reached")
+)
+
+// ConversionError describes a value that could not be copied into its
+// destination.
+type ConversionError struct {
+	// Path is the location of the value within the input, such as
+	// "Orders[3].Lines[0].Price". It is empty for the top-level value.
+	Path string
+	// Value is the source value, if it could be retrieved.
+	Value interface{}
+	// SrcType and DstType are the types of the source and destination.
+	SrcType reflect.Type
+	DstType reflect.Type
+	// Err is the underlying cause.
+	Err error
+}
+
+func newConversionError(path string, inValue reflect.Value, outType reflect.Type, cause error) *ConversionError {
+	err := &ConversionError{
+		Path:    path,
+		DstType: outType,
+		Err:     cause,
+	}
+	if inValue.IsValid() {
+		err.SrcType = inValue.Type()
+		if inValue.CanInterface() {
+			err.Value = inValue.Interface()
+		}
+	}
+	return err
+}
+
+func (e *ConversionError) Error() string {
+	msg := fmt.Sprintf("unable to convert %s (type %s) to type %s", e.Value, e.SrcType, e.DstType)
+	if e.Err != nil && e.Err != ErrUnconvertible {
+		msg += ": " + e.Err.Error()
+	}
+	if e.Path != "" {
+		msg = e.Path + ": " + msg
+	}
+	return msg
+}
+
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports every ConversionError as ErrUnconvertible, regardless of its
+// underlying cause.
+func (e *ConversionError) Is(target error) bool {
+	return target == ErrUnconvertible
+}
+
+// RequiredFieldError reports a destination field tagged required that was
+// left at its zero value by a copy.
+type RequiredFieldError struct {
+	// Path is the location of the field within the output, such as
+	// "Orders[3].CustomerID".
+	Path string
+	// Type is the type of the field.
+	Type reflect.Type
+}
+
+func (e *RequiredFieldError) Error() string {
+	return fmt.Sprintf("%s: required field of type %s is zero", e.Path, e.Type)
+}
+
+func (e *RequiredFieldError) Is(target error) bool {
+	return target == ErrRequired
+}
+
+// Errors is returned by a Copier created with WithCollectErrors and holds
+// every error encountered during the copy. Like the result of errors.Join,
+// it can be inspected with errors.Is and errors.As.
+type Errors []error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Is reports whether any of the errors matches target, like errors.Is.
+func (e Errors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the errors that matches target, like errors.As.
+func (e Errors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func fieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func keyPath(path string, key reflect.Value) string {
+	return path + "[" + fmt.Sprint(key) + "]"
+}
+
+func indexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
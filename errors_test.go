@@ -0,0 +1,156 @@
+package deepcopy
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type LocalOrderLine struct {
+	Price string
+}
+
+type LocalOrder struct {
+	Lines []LocalOrderLine
+}
+
+type LocalCart struct {
+	Orders []*LocalOrder
+}
+
+type PbOrderLine struct {
+	Price float64
+}
+
+type PbOrder struct {
+	Lines []PbOrderLine
+}
+
+type PbCart struct {
+	Orders []PbOrder
+}
+
+func TestConversionError(t *testing.T) {
+	input := LocalCart{
+		Orders: []*LocalOrder{
+			{Lines: []LocalOrderLine{{Price: "1.50"}}},
+			{Lines: []LocalOrderLine{{Price: "2.75"}, {Price: "free"}}},
+		},
+	}
+	err := DeepCopy(input, &PbCart{})
+	require.Error(t, err)
+	assert.Equal(t, "Orders[1].Lines[1].Price: unable to convert free (type string) to type float64", err.Error())
+	assert.True(t, errors.Is(err, ErrUnconvertible))
+
+	var convErr *ConversionError
+	require.True(t, errors.As(err, &convErr))
+	assert.Equal(t, "Orders[1].Lines[1].Price", convErr.Path)
+	assert.Equal(t, "free", convErr.Value)
+	assert.Equal(t, reflect.TypeOf(""), convErr.SrcType)
+	assert.Equal(t, reflect.TypeOf(float64(0)), convErr.DstType)
+}
+
+func TestConversionErrorCause(t *testing.T) {
+	errNegative := errors.New("negative duration")
+	copier := New(WithConverter(func(d time.Duration) (uint64, error) {
+		if d < 0 {
+			return 0, errNegative
+		}
+		return uint64(d), nil
+	}))
+	var out uint64
+	err := copier.Copy(-time.Second, &out)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errNegative))
+	assert.True(t, errors.Is(err, ErrUnconvertible))
+	assert.False(t, errors.Is(err, ErrOverflow))
+}
+
+func TestErrNotPointer(t *testing.T) {
+	err := DeepCopy(4, PbCart{})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrNotPointer))
+	assert.False(t, errors.Is(err, ErrUnconvertible))
+
+	err = DeepCopy(1, (*int)(nil))
+	assert.True(t, errors.Is(err, ErrNotPointer))
+	assert.EqualError(t, err, "expected pointer for arg1 but received nil *int")
+}
+
+type ImportRow struct {
+	Name    string
+	Age     string
+	Miles   string
+	Active  string
+	Ratings []string
+}
+
+type ImportRecord struct {
+	Name    string
+	Age     uint8
+	Miles   float64
+	Active  bool
+	Ratings []int64
+}
+
+func TestCollectErrors(t *testing.T) {
+	input := ImportRow{
+		Name:    "leia",
+		Age:     "old",
+		Miles:   "12.5",
+		Active:  "maybe",
+		Ratings: []string{"5", "five", "4"},
+	}
+
+	testCases := []struct {
+		name            string
+		copier          *Copier
+		expectedRespPtr interface{}
+		expectedPaths   []string
+	}{
+		{
+			name:   "collects every error",
+			copier: New(WithCollectErrors(0)),
+			expectedRespPtr: &ImportRecord{
+				Name:    "leia",
+				Miles:   12.5,
+				Ratings: []int64{5, 0, 4},
+			},
+			expectedPaths: []string{"Age", "Active", "Ratings[1]"},
+		},
+		{
+			name:   "stops at error limit",
+			copier: New(WithCollectErrors(2)),
+			expectedRespPtr: &ImportRecord{
+				Name:  "leia",
+				Miles: 12.5,
+			},
+			expectedPaths: []string{"Age", "Active"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &ImportRecord{}
+			err := tc.copier.Copy(input, out)
+			require.Error(t, err)
+			assert.Equal(t, tc.expectedRespPtr, out)
+			assert.True(t, errors.Is(err, ErrUnconvertible))
+			var firstErr *ConversionError
+			require.True(t, errors.As(err, &firstErr))
+			assert.Equal(t, "Age", firstErr.Path)
+
+			var errs Errors
+			require.True(t, errors.As(err, &errs))
+			paths := make([]string, len(errs))
+			for i, fieldErr := range errs {
+				var convErr *ConversionError
+				require.True(t, errors.As(fieldErr, &convErr))
+				paths[i] = convErr.Path
+			}
+			assert.Equal(t, tc.expectedPaths, paths)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package deepcopy
+
+// Clone returns a deep copy of v.
+func Clone[T any](v T) (T, error) {
+	var out T
+	err := DeepCopy(v, &out)
+	return out, err
+}
+
+// Convert copies src into a new value of type Dst, following the same
+// rules as DeepCopy.
+func Convert[Dst any](src any) (Dst, error) {
+	var out Dst
+	err := DeepCopy(src, &out)
+	return out, err
+}
+
+// ConvertSlice copies every element of src into a new slice of Dst,
+// following the same rules as DeepCopy. A nil src returns a nil slice.
+func ConvertSlice[Src, Dst any](src []Src) ([]Dst, error) {
+	if src == nil {
+		return nil, nil
+	}
+	var out []Dst
+	err := DeepCopy(src, &out)
+	return out, err
+}
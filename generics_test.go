@@ -0,0 +1,66 @@
+package deepcopy
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	teacher := &LocalTeacher{TeacherID: 4}
+	classroom := LocalClassroom{
+		Teacher1: LocalTeacher{TeacherID: 1},
+		Teacher2: teacher,
+	}
+
+	clone, err := Clone(classroom)
+	require.NoError(t, err)
+	assert.Equal(t, classroom, clone)
+	assert.NotSame(t, teacher, clone.Teacher2)
+
+	clonePtr, err := Clone(&classroom)
+	require.NoError(t, err)
+	assert.Equal(t, &classroom, clonePtr)
+	assert.NotSame(t, &classroom, clonePtr)
+
+	var nilClassroom *LocalClassroom
+	clonePtr, err = Clone(nilClassroom)
+	require.NoError(t, err)
+	assert.Nil(t, clonePtr)
+}
+
+func TestConvert(t *testing.T) {
+	teacher, err := Convert[PbTeacher](LocalTeacher{TeacherID: 4})
+	require.NoError(t, err)
+	assert.Equal(t, PbTeacher{TeacherId: 4}, teacher)
+
+	teacherPtr, err := Convert[*PbTeacher](&LocalTeacher{TeacherID: 4})
+	require.NoError(t, err)
+	assert.Equal(t, &PbTeacher{TeacherId: 4}, teacherPtr)
+
+	miles, err := Convert[uint32]("1200")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1200), miles)
+
+	_, err = Convert[float64]("far")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrUnconvertible))
+}
+
+func TestConvertSlice(t *testing.T) {
+	teachers, err := ConvertSlice[*LocalTeacher, PbTeacher]([]*LocalTeacher{
+		{TeacherID: 1},
+		{TeacherID: 2},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []PbTeacher{{TeacherId: 1}, {TeacherId: 2}}, teachers)
+
+	teachers, err = ConvertSlice[*LocalTeacher, PbTeacher](nil)
+	require.NoError(t, err)
+	assert.Nil(t, teachers)
+
+	_, err = ConvertSlice[string, int64]([]string{"1", "two"})
+	require.Error(t, err)
+	assert.Equal(t, "[1]: unable to convert two (type string) to type int64", err.Error())
+}
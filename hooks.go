@@ -0,0 +1,105 @@
+package deepcopy
+
+import (
+	"reflect"
+	"sync"
+)
+
+// BeforeDeepCopier is implemented by destination types that need to act
+// before a value is copied into them. src is the source value.
+type BeforeDeepCopier interface {
+	BeforeDeepCopy(src interface{}) error
+}
+
+// AfterDeepCopier is implemented by destination types that need to act
+// once a value has been copied into them, for example to compute derived
+// fields. src is the source value.
+type AfterDeepCopier interface {
+	AfterDeepCopy(src interface{}) error
+}
+
+// DeepCopierFrom is implemented by destination types that copy some source
+// values themselves. When DeepCopyFrom reports the value as handled, the
+// Copier neither copies it nor calls the other hooks.
+type DeepCopierFrom interface {
+	DeepCopyFrom(src interface{}) (handled bool, err error)
+}
+
+// hookSet records which hook interfaces a pointer type implements.
+type hookSet uint8
+
+const (
+	hookBefore hookSet = 1 << iota
+	hookAfter
+	hookFrom
+	// hooksChecked marks a cached entry, so that types without hooks
+	// are only checked once.
+	hooksChecked
+)
+
+var (
+	beforeDeepCopierType = reflect.TypeOf((*BeforeDeepCopier)(nil)).Elem()
+	afterDeepCopierType  = reflect.TypeOf((*AfterDeepCopier)(nil)).Elem()
+	deepCopierFromType   = reflect.TypeOf((*DeepCopierFrom)(nil)).Elem()
+
+	// hookCache maps destination types to their hookSet.
+	hookCache sync.Map
+)
+
+// hooksFor returns the hooks implemented by pointers to values of type t.
+// Hooks are not looked up on pointer and interface destinations, whose
+// elements are checked once they are copied.
+func hooksFor(t reflect.Type) hookSet {
+	if cached, ok := hookCache.Load(t); ok {
+		return cached.(hookSet) &^ hooksChecked
+	}
+	hooks := hooksChecked
+	if k := t.Kind(); k != reflect.Ptr && k != reflect.Interface {
+		ptr := reflect.PtrTo(t)
+		if ptr.Implements(beforeDeepCopierType) {
+			hooks |= hookBefore
+		}
+		if ptr.Implements(afterDeepCopierType) {
+			hooks |= hookAfter
+		}
+		if ptr.Implements(deepCopierFromType) {
+			hooks |= hookFrom
+		}
+	}
+	hookCache.Store(t, hooks)
+	return hooks &^ hooksChecked
+}
+
+// copyWithHooks copies inValue into the addressable outValue, calling the
+// hooks implemented by outValue's type around the copy. Hook errors are
+// returned as the cause of a *ConversionError.
+func (s *copyState) copyWithHooks(path string, inValue, outValue reflect.Value, hooks hookSet) error {
+	dst := outValue.Addr().Interface()
+	var src interface{}
+	if inValue.CanInterface() {
+		src = inValue.Interface()
+	}
+	if hooks&hookFrom != 0 {
+		handled, err := dst.(DeepCopierFrom).DeepCopyFrom(src)
+		if err != nil {
+			return newConversionError(path, inValue, outValue.Type(), err)
+		}
+		if handled {
+			return nil
+		}
+	}
+	if hooks&hookBefore != 0 {
+		if err := dst.(BeforeDeepCopier).BeforeDeepCopy(src); err != nil {
+			return newConversionError(path, inValue, outValue.Type(), err)
+		}
+	}
+	if err := s.copyValue(path, inValue, outValue); err != nil {
+		return err
+	}
+	if hooks&hookAfter != 0 {
+		if err := dst.(AfterDeepCopier).AfterDeepCopy(src); err != nil {
+			return newConversionError(path, inValue, outValue.Type(), err)
+		}
+	}
+	return nil
+}
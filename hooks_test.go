@@ -0,0 +1,123 @@
+package deepcopy
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type LocalDriver struct {
+	FirstName string
+	LastName  string
+	License   string
+	Location  string
+}
+
+type PbDriver struct {
+	FirstName string
+	LastName  string
+	FullName  string
+	License   string
+	Location  Coordinates
+	copies    int
+}
+
+func (d *PbDriver) BeforeDeepCopy(src interface{}) error {
+	if driver, ok := src.(LocalDriver); ok && driver.License == "" {
+		return errors.New("missing license")
+	}
+	d.copies++
+	return nil
+}
+
+func (d *PbDriver) AfterDeepCopy(src interface{}) error {
+	d.FullName = d.FirstName + " " + d.LastName
+	return nil
+}
+
+type Coordinates struct {
+	Lat float64
+	Lng float64
+}
+
+func (c *Coordinates) DeepCopyFrom(src interface{}) (bool, error) {
+	s, ok := src.(string)
+	if !ok {
+		return false, nil
+	}
+	if _, err := fmt.Sscanf(s, "%f,%f", &c.Lat, &c.Lng); err != nil {
+		return true, fmt.Errorf("invalid coordinates %q", s)
+	}
+	return true, nil
+}
+
+func TestHooks(t *testing.T) {
+	jane := LocalDriver{FirstName: "Jane", LastName: "Doe", License: "D123", Location: "39.7,-104.9"}
+	janeOut := PbDriver{
+		FirstName: "Jane",
+		LastName:  "Doe",
+		FullName:  "Jane Doe",
+		License:   "D123",
+		Location:  Coordinates{Lat: 39.7, Lng: -104.9},
+		copies:    1,
+	}
+
+	testCases := []struct {
+		name            string
+		input           interface{}
+		outputPtr       interface{}
+		expectedRespPtr interface{}
+		expectedErr     error
+	}{
+		{
+			name:            "struct",
+			input:           jane,
+			outputPtr:       &PbDriver{},
+			expectedRespPtr: &janeOut,
+		},
+		{
+			name:            "slice elements",
+			input:           []*LocalDriver{&jane},
+			outputPtr:       &[]PbDriver{},
+			expectedRespPtr: &[]PbDriver{janeOut},
+		},
+		{
+			name:            "map values",
+			input:           map[string]LocalDriver{"jane": jane},
+			outputPtr:       &map[string]*PbDriver{},
+			expectedRespPtr: &map[string]*PbDriver{"jane": &janeOut},
+		},
+		{
+			name:            "DeepCopyFrom not handling the source",
+			input:           Coordinates{Lat: 1, Lng: 2},
+			outputPtr:       &Coordinates{},
+			expectedRespPtr: &Coordinates{Lat: 1, Lng: 2},
+		},
+		{
+			name:        "BeforeDeepCopy error",
+			input:       []LocalDriver{jane, {FirstName: "John"}},
+			outputPtr:   &[]PbDriver{},
+			expectedErr: errors.New("[1]: unable to convert {John   } (type deepcopy.LocalDriver) to type deepcopy.PbDriver: missing license"),
+		},
+		{
+			name:        "DeepCopyFrom error",
+			input:       LocalDriver{License: "D123", Location: "downtown"},
+			outputPtr:   &PbDriver{},
+			expectedErr: errors.New("Location: unable to convert downtown (type string) to type deepcopy.Coordinates: invalid coordinates \"downtown\""),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := DeepCopy(tc.input, tc.outputPtr)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedRespPtr, tc.outputPtr)
+			}
+		})
+	}
+}
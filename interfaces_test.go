@@ -0,0 +1,122 @@
+package deepcopy
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type Vehicle struct {
+	Vin   string
+	Miles uint64
+}
+
+func (v Vehicle) String() string {
+	return v.Vin
+}
+
+type LocalEvent struct {
+	Payload interface{}
+	Tags    []interface{}
+	Subject interface{}
+	Err     error
+}
+
+type PbEvent struct {
+	Payload interface{}
+	Tags    []interface{}
+	Subject fmt.Stringer
+	Err     error
+}
+
+type StringEvent struct {
+	Subject string
+}
+
+func TestInterfaces(t *testing.T) {
+	vehicle := &Vehicle{Vin: "1HGCM82633A004352", Miles: 1200}
+	errFlat := errors.New("flat tire")
+	input := LocalEvent{
+		Payload: vehicle,
+		Tags: []interface{}{
+			"urgent",
+			map[string]interface{}{"miles": []int{1, 2}},
+			nil,
+		},
+		Subject: Vehicle{Vin: "1HGCM82633A004352"},
+		Err:     errFlat,
+	}
+	out := PbEvent{}
+	err := DeepCopy(input, &out)
+	require.NoError(t, err)
+
+	require.IsType(t, &Vehicle{}, out.Payload)
+	assert.Equal(t, vehicle, out.Payload)
+	assert.NotSame(t, vehicle, out.Payload)
+
+	assert.Equal(t, input.Tags, out.Tags)
+	out.Tags[1].(map[string]interface{})["miles"].([]int)[0] = 5
+	assert.Equal(t, 1, input.Tags[1].(map[string]interface{})["miles"].([]int)[0])
+
+	assert.Equal(t, Vehicle{Vin: "1HGCM82633A004352"}, out.Subject)
+	assert.Same(t, errFlat, out.Err)
+}
+
+func TestInterfaceConversions(t *testing.T) {
+	testCases := []struct {
+		name            string
+		input           interface{}
+		outputPtr       interface{}
+		expectedRespPtr interface{}
+		expectedErr     error
+	}{
+		{
+			name: "interface to concrete type",
+			input: LocalEvent{
+				Subject: "plain string",
+			},
+			outputPtr: &StringEvent{},
+			expectedRespPtr: &StringEvent{
+				Subject: "plain string",
+			},
+		},
+		{
+			name: "concrete type to interface",
+			input: PbTeacher{
+				TeacherId: 4,
+			},
+			outputPtr:       new(interface{}),
+			expectedRespPtr: &[]interface{}{PbTeacher{TeacherId: 4}}[0],
+		},
+		{
+			name: "pointer argument to interface",
+			input: &PbTeacher{
+				TeacherId: 4,
+			},
+			outputPtr:       new(interface{}),
+			expectedRespPtr: &[]interface{}{PbTeacher{TeacherId: 4}}[0],
+		},
+		{
+			name: "value not implementing interface",
+			input: LocalEvent{
+				Subject: 4,
+			},
+			outputPtr:   &PbEvent{},
+			expectedErr: errors.New("Subject: unable to convert %!s(int=4) (type interface {}) to type fmt.Stringer: type int does not implement fmt.Stringer"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := DeepCopy(tc.input, tc.outputPtr)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedRespPtr, tc.outputPtr)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+// Code generated by deepcopy-gen. DO NOT EDIT.
+
+package gentest
+
+import (
+	"fmt"
+	"github.com/fluidtruck/deepcopy"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConvertPbUserToUser copies in into out following the same rules as
+// deepcopy.DeepCopy, without using reflection.
+func ConvertPbUserToUser(in *PbUser, out *User) error {
+	return convertPbUserToUser(in, out)
+}
+
+// ConvertAddressToPbAddress copies in into out following the same rules as
+// deepcopy.DeepCopy, without using reflection.
+func ConvertAddressToPbAddress(in *Address, out *PbAddress) error {
+	return convertAddressToPbAddress(in, out)
+}
+
+// ConvertUserToUserSummary copies in into out following the same rules as
+// deepcopy.DeepCopy, without using reflection.
+func ConvertUserToUserSummary(in *User, out *UserSummary) error {
+	return convertUserToUserSummary(in, out)
+}
+
+// ConvertUserSummaryToUser copies in into out following the same rules as
+// deepcopy.DeepCopy, without using reflection.
+func ConvertUserSummaryToUser(in *UserSummary, out *User) error {
+	return convertUserSummaryToUser(in, out)
+}
+
+func convertPbUserToUser(in *PbUser, out *User) error {
+	if in.PbAudit != nil && in.PbAudit.UpdatedBy != "" {
+		if out.Audit == nil {
+			out.Audit = new(Audit)
+		}
+		out.Audit.UpdatedBy = in.PbAudit.UpdatedBy
+	}
+	if out.Audit == nil {
+		out.Audit = new(Audit)
+	}
+	if in.PbAudit != nil {
+		out.Audit.Revision = int64(in.PbAudit.Revision)
+	} else {
+		out.Audit.Revision = 0
+	}
+	if in.UserId != nil {
+		out.UserID = *in.UserId
+	}
+	if in.FullName != "" {
+		out.Name = in.FullName
+	}
+	if in.Age != "" {
+		if v2, err := deepcopy.ParseDefault.ParseInt(in.Age); err != nil {
+			return &deepcopy.ConversionError{Path: "Age", Value: in.Age, SrcType: reflect.TypeOf(in.Age), DstType: reflect.TypeOf(out.Age), Err: err}
+		} else {
+			switch v3 := v2; {
+			case v3 < math.MinInt:
+				return &deepcopy.ConversionError{Path: "Age", Value: in.Age, SrcType: reflect.TypeOf(in.Age), DstType: reflect.TypeOf(out.Age), Err: deepcopy.ErrOverflow}
+			case v3 > math.MaxInt:
+				return &deepcopy.ConversionError{Path: "Age", Value: in.Age, SrcType: reflect.TypeOf(in.Age), DstType: reflect.TypeOf(out.Age), Err: deepcopy.ErrOverflow}
+			default:
+				out.Age = int(v3)
+			}
+		}
+	}
+	if in.Verified != "" {
+		switch strings.ToLower(in.Verified) {
+		case "t", "true":
+			out.Verified = true
+		case "f", "false":
+			out.Verified = false
+		default:
+			return &deepcopy.ConversionError{Path: "Verified", Value: in.Verified, SrcType: reflect.TypeOf(in.Verified), DstType: reflect.TypeOf(out.Verified), Err: deepcopy.ErrUnconvertible}
+		}
+	}
+	if in.CreatedAt != nil {
+		out.CreatedAt = in.CreatedAt.AsTime()
+	}
+	if in.UpdatedAt != nil {
+		t4 := in.UpdatedAt.AsTime()
+		out.UpdatedAt = &t4
+	}
+	if in.Timeout != nil {
+		if err := in.Timeout.CheckValid(); err != nil {
+			return &deepcopy.ConversionError{Path: "Timeout", Value: in.Timeout, SrcType: reflect.TypeOf(in.Timeout), DstType: reflect.TypeOf(out.Timeout), Err: err}
+		}
+		d5 := in.Timeout.AsDuration()
+		out.Timeout = d5
+	}
+	if in.Address != nil {
+		if err := convertPbAddressToAddress(in.Address, &out.Address); err != nil {
+			return deepcopyErrorAt("Address", err)
+		}
+	}
+	if in.Phones != nil {
+		if len(in.Phones) != 2 {
+			return &deepcopy.ConversionError{Path: "Phones", Value: in.Phones, SrcType: reflect.TypeOf(in.Phones), DstType: reflect.TypeOf(out.Phones), Err: fmt.Errorf("length %d does not match length 2", len(in.Phones))}
+		}
+		var v6 [2]string
+		for i7 := range v6 {
+			v6[i7] = in.Phones[i7]
+		}
+		out.Phones = v6
+	}
+	if in.Scores != nil {
+		v8 := make(map[string]int64, len(in.Scores))
+		for k9, e10 := range in.Scores {
+			var k11 string
+			k11 = k9
+			var e12 int64
+			if e10 != nil {
+				e12 = int64(*e10)
+			}
+			v8[k11] = e12
+		}
+		out.Scores = v8
+	}
+	if in.Roles != nil {
+		v14 := make([]*Role, len(in.Roles))
+		for i15 := range in.Roles {
+			v16 := new(Role)
+			if err := convertPbRoleToRole(&in.Roles[i15], v16); err != nil {
+				return deepcopyErrorAt("Roles["+strconv.Itoa(i15)+"]", err)
+			}
+			v14[i15] = v16
+		}
+		out.Roles = v14
+	}
+	if in.Metadata != nil {
+		if err := deepcopy.DeepCopy(in.Metadata, &out.Metadata); err != nil {
+			return deepcopyErrorAt("Metadata", err)
+		}
+	}
+	if in.Labels != nil {
+		out.Labels = (*in.Labels).Clone()
+	}
+	return nil
+}
+
+func convertAddressToPbAddress(in *Address, out *PbAddress) error {
+	if in.Street != "" {
+		out.Street = in.Street
+	}
+	out.City = in.City
+	if in.Unit != 0 {
+		switch v1 := in.Unit; {
+		case v1 < math.MinInt16:
+			return &deepcopy.ConversionError{Path: "Unit", Value: in.Unit, SrcType: reflect.TypeOf(in.Unit), DstType: reflect.TypeOf(out.Unit), Err: deepcopy.ErrOverflow}
+		case v1 > math.MaxInt16:
+			return &deepcopy.ConversionError{Path: "Unit", Value: in.Unit, SrcType: reflect.TypeOf(in.Unit), DstType: reflect.TypeOf(out.Unit), Err: deepcopy.ErrOverflow}
+		default:
+			out.Unit = int16(v1)
+		}
+	}
+	if in.Latitude != 0 {
+		switch v2 := in.Latitude; {
+		case v2 > math.MaxFloat32 && !math.IsInf(v2, 1):
+			return &deepcopy.ConversionError{Path: "Latitude", Value: in.Latitude, SrcType: reflect.TypeOf(in.Latitude), DstType: reflect.TypeOf(out.Latitude), Err: deepcopy.ErrOverflow}
+		case v2 < -math.MaxFloat32 && !math.IsInf(v2, -1):
+			return &deepcopy.ConversionError{Path: "Latitude", Value: in.Latitude, SrcType: reflect.TypeOf(in.Latitude), DstType: reflect.TypeOf(out.Latitude), Err: deepcopy.ErrOverflow}
+		default:
+			out.Latitude = float32(v2)
+		}
+	}
+	return nil
+}
+
+func convertUserToUserSummary(in *User, out *UserSummary) error {
+	if in.Name != "" {
+		out.Name = in.Name
+	}
+	if in.Age != 0 {
+		out.Age = strconv.FormatInt(int64(in.Age), 10)
+	}
+	if in.Verified {
+		if in.Verified {
+			out.Verified = "true"
+		} else {
+			out.Verified = "false"
+		}
+	}
+	if in.Timeout != 0 {
+		out.Timeout = strconv.FormatInt(int64(in.Timeout), 10)
+	}
+	out.City = in.Address.City
+	if in.Audit != nil && in.Audit.UpdatedBy != "" {
+		out.Editor = in.Audit.UpdatedBy
+	}
+	if out.Name == "" {
+		return &deepcopy.RequiredFieldError{Path: "Name", Type: reflect.TypeOf((*string)(nil)).Elem()}
+	}
+	if err := out.AfterDeepCopy(*in); err != nil {
+		return &deepcopy.ConversionError{Path: "", Value: *in, SrcType: reflect.TypeOf(*in), DstType: reflect.TypeOf(*out), Err: err}
+	}
+	return nil
+}
+
+func convertUserSummaryToUser(in *UserSummary, out *User) error {
+	if in.Name != "" {
+		out.Name = in.Name
+	}
+	out.Address.City = in.City
+	if in.Editor != "" {
+		if out.Audit == nil {
+			out.Audit = new(Audit)
+		}
+		out.Audit.UpdatedBy = in.Editor
+	}
+	if in.Age != "" {
+		if v1, err := deepcopy.ParseDefault.ParseInt(in.Age); err != nil {
+			return &deepcopy.ConversionError{Path: "Age", Value: in.Age, SrcType: reflect.TypeOf(in.Age), DstType: reflect.TypeOf(out.Age), Err: err}
+		} else {
+			switch v2 := v1; {
+			case v2 < math.MinInt:
+				return &deepcopy.ConversionError{Path: "Age", Value: in.Age, SrcType: reflect.TypeOf(in.Age), DstType: reflect.TypeOf(out.Age), Err: deepcopy.ErrOverflow}
+			case v2 > math.MaxInt:
+				return &deepcopy.ConversionError{Path: "Age", Value: in.Age, SrcType: reflect.TypeOf(in.Age), DstType: reflect.TypeOf(out.Age), Err: deepcopy.ErrOverflow}
+			default:
+				out.Age = int(v2)
+			}
+		}
+	}
+	if in.Verified != "" {
+		switch strings.ToLower(in.Verified) {
+		case "t", "true":
+			out.Verified = true
+		case "f", "false":
+			out.Verified = false
+		default:
+			return &deepcopy.ConversionError{Path: "Verified", Value: in.Verified, SrcType: reflect.TypeOf(in.Verified), DstType: reflect.TypeOf(out.Verified), Err: deepcopy.ErrUnconvertible}
+		}
+	}
+	if in.Timeout != "" {
+		if v3, err := deepcopy.ParseDefault.ParseInt(in.Timeout); err != nil {
+			return &deepcopy.ConversionError{Path: "Timeout", Value: in.Timeout, SrcType: reflect.TypeOf(in.Timeout), DstType: reflect.TypeOf(out.Timeout), Err: err}
+		} else {
+			out.Timeout = time.Duration(v3)
+		}
+	}
+	return nil
+}
+
+func convertPbAddressToAddress(in *PbAddress, out *Address) error {
+	if in.Street != "" {
+		out.Street = in.Street
+	}
+	out.City = in.City
+	if in.Unit != 0 {
+		out.Unit = int64(in.Unit)
+	}
+	if in.Latitude != 0 {
+		out.Latitude = float64(in.Latitude)
+	}
+	return nil
+}
+
+func convertPbRoleToRole(in *PbRole, out *Role) error {
+	if in.Name != "" {
+		out.Name = in.Name
+	}
+	if in.Level != 0 {
+		out.Level = int64(in.Level)
+	}
+	return nil
+}
+
+// deepcopyErrorAt adds path, the location of a nested value, to the
+// path of an error returned when copying it. Paths are only built
+// when a copy fails.
+func deepcopyErrorAt(path string, err error) error {
+	var errPath *string
+	switch err := err.(type) {
+	case *deepcopy.ConversionError:
+		errPath = &err.Path
+	case *deepcopy.RequiredFieldError:
+		errPath = &err.Path
+	default:
+		return err
+	}
+	if *errPath != "" && !strings.HasPrefix(*errPath, "[") {
+		path += "."
+	}
+	*errPath = path + *errPath
+	return err
+}
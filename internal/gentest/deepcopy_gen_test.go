@@ -0,0 +1,223 @@
+package gentest
+
+import (
+	"errors"
+	"github.com/fluidtruck/deepcopy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+func TestConvertPbUserToUser(t *testing.T) {
+	userId := int64(42)
+	score := int32(7)
+	createdAt := time.Date(2022, 8, 17, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		input       PbUser
+		outputPtr   *User
+		expectedErr error
+	}{
+		{
+			name: "all fields",
+			input: PbUser{
+				PbAudit:   &PbAudit{UpdatedBy: "admin", Revision: 3},
+				UserId:    &userId,
+				FullName:  "Jane Doe",
+				Age:       "37",
+				Verified:  "TRUE",
+				CreatedAt: timestamppb.New(createdAt),
+				UpdatedAt: timestamppb.New(createdAt.Add(time.Hour)),
+				Timeout:   durationpb.New(30 * time.Minute),
+				Address:   &PbAddress{Street: "1 Main St"},
+				Phones:    []string{"555-0100", "555-0101"},
+				Scores:    map[string]*int32{"math": &score, "art": nil},
+				Roles:     []PbRole{{Name: "admin", Level: 3}},
+				Metadata:  map[string]interface{}{"tags": []string{"a", "b"}},
+				Labels:    &[]Labels{NewLabels("beta")}[0],
+				internal:  "hidden",
+			},
+			outputPtr: &User{},
+		},
+		{
+			name:  "zero values leave destination untouched",
+			input: PbUser{},
+			outputPtr: &User{
+				UserID:  1,
+				Name:    "John Doe",
+				Address: Address{Street: "2 Main St", City: "Denver"},
+			},
+		},
+		{
+			name:        "unparsable string",
+			input:       PbUser{Age: "thirty"},
+			outputPtr:   &User{},
+			expectedErr: errors.New("Age: unable to convert thirty (type string) to type int"),
+		},
+		{
+			name:        "slice length mismatch",
+			input:       PbUser{Phones: []string{"555-0100"}},
+			outputPtr:   &User{},
+			expectedErr: errors.New("Phones: unable to convert [555-0100] (type []string) to type [2]string: length 1 does not match length 2"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expected := *tc.outputPtr
+			reflectErr := deepcopy.DeepCopy(tc.input, &expected)
+
+			err := ConvertPbUserToUser(&tc.input, tc.outputPtr)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				require.Error(t, reflectErr)
+				assert.Equal(t, reflectErr.Error(), err.Error())
+			} else {
+				require.NoError(t, err)
+				require.NoError(t, reflectErr)
+				assert.Equal(t, &expected, tc.outputPtr)
+			}
+		})
+	}
+}
+
+func TestConvertPbUserToUserInvalidDuration(t *testing.T) {
+	input := PbUser{Timeout: &durationpb.Duration{Seconds: 1, Nanos: -1}}
+	err := ConvertPbUserToUser(&input, &User{})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, deepcopy.ErrUnconvertible))
+
+	reflectErr := deepcopy.DeepCopy(input, &User{})
+	require.Error(t, reflectErr)
+	assert.Equal(t, reflectErr.Error(), err.Error())
+}
+
+type Quota struct {
+	Limit int
+}
+
+func (q *Quota) BeforeDeepCopy(src interface{}) error {
+	if src.(Quota).Limit < 0 {
+		return errors.New("negative limit")
+	}
+	return nil
+}
+
+type Plan struct {
+	Quota Quota
+}
+
+func TestConvertPbUserToUserInvalidMetadata(t *testing.T) {
+	testCases := []struct {
+		name     string
+		metadata interface{}
+		path     string
+	}{
+		{name: "value", metadata: Quota{Limit: -1}, path: "Metadata"},
+		{name: "field", metadata: Plan{Quota: Quota{Limit: -1}}, path: "Metadata.Quota"},
+		{name: "map value", metadata: map[string]interface{}{"quota": Quota{Limit: -1}}, path: "Metadata[quota]"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := PbUser{Metadata: tc.metadata}
+			err := ConvertPbUserToUser(&input, &User{})
+			var convErr *deepcopy.ConversionError
+			require.True(t, errors.As(err, &convErr))
+			assert.Equal(t, tc.path, convErr.Path)
+
+			reflectErr := deepcopy.DeepCopy(input, &User{})
+			require.Error(t, reflectErr)
+			assert.Equal(t, reflectErr.Error(), err.Error())
+		})
+	}
+}
+
+func TestConvertAddressToPbAddress(t *testing.T) {
+	out := PbAddress{Street: "1 Main St", City: "Denver"}
+	err := ConvertAddressToPbAddress(&Address{}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, PbAddress{Street: "1 Main St"}, out)
+}
+
+func TestConvertAddressToPbAddressOverflow(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    Address
+		expected PbAddress
+		overflow bool
+	}{
+		{
+			name:     "in range",
+			input:    Address{Unit: 12, Latitude: 39.75},
+			expected: PbAddress{Unit: 12, Latitude: 39.75},
+		},
+		{
+			name:     "unit too large",
+			input:    Address{Unit: 40000},
+			overflow: true,
+		},
+		{
+			name:     "latitude too large",
+			input:    Address{Latitude: 1e300},
+			overflow: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := PbAddress{}
+			err := ConvertAddressToPbAddress(&tc.input, &out)
+			reflectErr := deepcopy.DeepCopy(tc.input, &PbAddress{})
+			if tc.overflow {
+				assert.True(t, errors.Is(err, deepcopy.ErrOverflow))
+				assert.Equal(t, reflectErr.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, reflectErr)
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestConvertUserSummary(t *testing.T) {
+	user := User{
+		Audit:    &Audit{UpdatedBy: "admin"},
+		Age:      37,
+		Verified: true,
+		Timeout:  time.Second,
+		Name:     "Jane Doe",
+		Address:  Address{Street: "1 Main St", City: "Denver"},
+	}
+	summary := UserSummary{}
+	err := ConvertUserToUserSummary(&user, &summary)
+	require.NoError(t, err)
+	assert.Equal(t, UserSummary{Name: "Jane Doe", City: "Denver", Editor: "admin", Label: "Jane Doe (Denver)", Age: "37", Verified: "true", Timeout: "1000000000"}, summary)
+	expectedSummary := UserSummary{}
+	err = deepcopy.DeepCopy(user, &expectedSummary)
+	require.NoError(t, err)
+	assert.Equal(t, expectedSummary, summary)
+
+	expected := User{}
+	err = deepcopy.DeepCopy(summary, &expected)
+	require.NoError(t, err)
+	out := User{}
+	err = ConvertUserSummaryToUser(&summary, &out)
+	require.NoError(t, err)
+	assert.Equal(t, expected, out)
+	assert.Equal(t, &Audit{UpdatedBy: "admin"}, out.Audit)
+}
+
+func TestConvertUserToUserSummaryRequired(t *testing.T) {
+	user := User{Address: Address{City: "Denver"}}
+	err := ConvertUserToUserSummary(&user, &UserSummary{})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, deepcopy.ErrRequired))
+
+	reflectErr := deepcopy.DeepCopy(user, &UserSummary{})
+	require.Error(t, reflectErr)
+	assert.Equal(t, reflectErr.Error(), err.Error())
+}
@@ -0,0 +1,108 @@
+// Package gentest holds types converted by deepcopy-gen, so that the
+// generated code can be compared against deepcopy.DeepCopy.
+package gentest
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+//go:generate go run ../../cmd/deepcopy-gen -type PbUser:User:ConvertPbUserToUser -type Address:PbAddress -type User:UserSummary -type UserSummary:User
+
+type PbUser struct {
+	*PbAudit
+	UserId    *int64
+	FullName  string `dc:"name"`
+	Age       string
+	Verified  string
+	CreatedAt *timestamppb.Timestamp
+	UpdatedAt *timestamppb.Timestamp
+	Timeout   *durationpb.Duration
+	Address   *PbAddress
+	Phones    []string
+	Scores    map[string]*int32
+	Roles     []PbRole
+	Metadata  interface{}
+	Labels    *Labels
+	internal  string
+}
+
+type PbAudit struct {
+	UpdatedBy string
+	Revision  int32
+}
+
+type PbAddress struct {
+	Street   string
+	City     string
+	Unit     int16
+	Latitude float32
+}
+
+type PbRole struct {
+	Name  string
+	Level int32
+}
+
+type User struct {
+	*Audit
+	UserID    int64
+	Name      string
+	Age       int
+	Verified  bool
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	Timeout   time.Duration
+	Address   Address
+	Phones    [2]string
+	Scores    map[string]int64
+	Roles     []*Role
+	Metadata  interface{}
+	Labels    Labels
+}
+
+type Audit struct {
+	UpdatedBy string
+	Revision  int64 `dc:",copyzero"`
+}
+
+type Address struct {
+	Street   string
+	City     string `dc:",copyzero"`
+	Unit     int64
+	Latitude float64
+}
+
+type Role struct {
+	Name  string
+	Level int64
+}
+
+type UserSummary struct {
+	Name     string `dc:",required"`
+	City     string `dc:"Address.City"`
+	Editor   string `dc:"Audit.UpdatedBy"`
+	Label    string
+	Age      string
+	Verified string
+	Timeout  string
+}
+
+func (s *UserSummary) AfterDeepCopy(src interface{}) error {
+	s.Label = s.Name + " (" + s.City + ")"
+	return nil
+}
+
+// Labels keeps its values unexported, so it is copied with Clone.
+type Labels struct {
+	values []string
+}
+
+func NewLabels(values ...string) Labels {
+	return Labels{values: values}
+}
+
+func (l Labels) Clone() Labels {
+	return Labels{values: append([]string(nil), l.values...)}
+}
@@ -0,0 +1,111 @@
+package deepcopy
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type LocalConfig struct {
+	Settings map[string]string
+	Teachers map[string]*LocalTeacher
+	Limits   map[int32]uint
+	Rates    map[string]string
+}
+
+type PbConfig struct {
+	Settings map[string]string
+	Teachers map[string]PbTeacher
+	Limits   map[int64]uint64
+	Rates    map[string]float64
+}
+
+func TestMaps(t *testing.T) {
+	testCases := []struct {
+		name            string
+		copier          *Copier
+		input           interface{}
+		outputPtr       interface{}
+		expectedRespPtr interface{}
+		expectedErr     error
+	}{
+		{
+			name:   "map values are converted",
+			copier: New(),
+			input: LocalConfig{
+				Teachers: map[string]*LocalTeacher{
+					"first":  {TeacherID: 1},
+					"second": {TeacherID: 2},
+					"absent": nil,
+				},
+			},
+			outputPtr: &PbConfig{},
+			expectedRespPtr: &PbConfig{
+				Teachers: map[string]PbTeacher{
+					"first":  {TeacherId: 1},
+					"second": {TeacherId: 2},
+					"absent": {},
+				},
+			},
+		},
+		{
+			name:   "map keys are converted",
+			copier: New(),
+			input: LocalConfig{
+				Limits: map[int32]uint{4: 40, 5: 50},
+			},
+			outputPtr: &PbConfig{},
+			expectedRespPtr: &PbConfig{
+				Limits: map[int64]uint64{4: 40, 5: 50},
+			},
+		},
+		{
+			name:   "empty map stays empty",
+			copier: New(WithZeroValues()),
+			input: LocalConfig{
+				Settings: map[string]string{},
+			},
+			outputPtr: &PbConfig{
+				Settings: map[string]string{"stale": "value"},
+				Limits:   map[int64]uint64{1: 1},
+			},
+			expectedRespPtr: &PbConfig{
+				Settings: map[string]string{},
+			},
+		},
+		{
+			name:   "bad map value reports key in path",
+			copier: New(),
+			input: LocalConfig{
+				Rates: map[string]string{"daily": "cheap"},
+			},
+			outputPtr:   &PbConfig{},
+			expectedErr: errors.New("Rates[daily]: unable to convert cheap (type string) to type float64"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.copier.Copy(tc.input, tc.outputPtr)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedRespPtr, tc.outputPtr)
+			}
+		})
+	}
+}
+
+func TestMapsAreNotShared(t *testing.T) {
+	input := LocalConfig{
+		Settings: map[string]string{"region": "west"},
+	}
+	out := LocalConfig{}
+	err := DeepCopy(input, &out)
+	require.NoError(t, err)
+
+	out.Settings["region"] = "east"
+	assert.Equal(t, "west", input.Settings["region"])
+}
@@ -0,0 +1,133 @@
+package deepcopy
+
+import (
+	"reflect"
+	"sync"
+)
+
+// copyMethod is a method a type provides to copy its own values: one of
+// DeepCopyInto(out *T), DeepCopy() *T or Clone() T.
+type copyMethod struct {
+	name string
+	fn   reflect.Value
+}
+
+// copyMethodCache maps types to their *copyMethod, or nil when they have
+// none.
+var copyMethodCache sync.Map
+
+// copyMethodFor returns the copy method of t, preferring DeepCopyInto over
+// DeepCopy over Clone, or nil when t has none. Methods with other
+// signatures are ignored.
+func copyMethodFor(t reflect.Type) *copyMethod {
+	if cached, ok := copyMethodCache.Load(t); ok {
+		return cached.(*copyMethod)
+	}
+	var found *copyMethod
+	if k := t.Kind(); k != reflect.Ptr && k != reflect.Interface {
+		ptr := reflect.PtrTo(t)
+		for _, name := range []string{"DeepCopyInto", "DeepCopy", "Clone"} {
+			method, ok := ptr.MethodByName(name)
+			if !ok {
+				continue
+			}
+			mt := method.Type
+			var matches bool
+			switch name {
+			case "DeepCopyInto":
+				matches = mt.NumIn() == 2 && mt.In(1) == ptr && mt.NumOut() == 0
+			case "DeepCopy":
+				matches = mt.NumIn() == 1 && mt.NumOut() == 1 && mt.Out(0) == ptr
+			case "Clone":
+				matches = mt.NumIn() == 1 && mt.NumOut() == 1 && mt.Out(0) == t
+			}
+			if matches {
+				found = &copyMethod{name: name, fn: method.Func}
+				break
+			}
+		}
+	}
+	copyMethodCache.Store(t, found)
+	return found
+}
+
+// runningKey identifies a value whose copy method is running.
+type runningKey struct {
+	addr uintptr
+	t    reflect.Type
+}
+
+// runningCopyMethods counts the copy methods running for each runningKey.
+// Methods that call DeepCopy on their own receiver are not called again
+// for it, and it is copied field by field instead.
+var runningCopyMethods = struct {
+	sync.Mutex
+	counts map[runningKey]int
+}{counts: make(map[runningKey]int)}
+
+// runningKeyFor returns the key of inValue, if it can be identified: by
+// address, or only by type for zero-sized values, which may share an
+// address.
+func runningKeyFor(inValue reflect.Value) (runningKey, bool) {
+	key := runningKey{t: inValue.Type()}
+	if key.t.Size() == 0 {
+		return key, true
+	}
+	if !inValue.CanAddr() {
+		return key, false
+	}
+	key.addr = inValue.Addr().Pointer()
+	return key, true
+}
+
+// copyMethodRunning reports whether a copy method is running for inValue.
+func copyMethodRunning(inValue reflect.Value) bool {
+	key, ok := runningKeyFor(inValue)
+	if !ok {
+		return false
+	}
+	runningCopyMethods.Lock()
+	defer runningCopyMethods.Unlock()
+	return runningCopyMethods.counts[key] > 0
+}
+
+// startCopyMethod records that a copy method is running for the value inPtr
+// points to, and returns the function to call when it returns.
+func startCopyMethod(inPtr reflect.Value) func() {
+	key, _ := runningKeyFor(inPtr.Elem())
+	runningCopyMethods.Lock()
+	runningCopyMethods.counts[key]++
+	runningCopyMethods.Unlock()
+	return func() {
+		runningCopyMethods.Lock()
+		if runningCopyMethods.counts[key]--; runningCopyMethods.counts[key] == 0 {
+			delete(runningCopyMethods.counts, key)
+		}
+		runningCopyMethods.Unlock()
+	}
+}
+
+// copyWithMethod copies inValue into the addressable outValue, both of the
+// method's type, by calling the method.
+func copyWithMethod(method *copyMethod, inValue, outValue reflect.Value) {
+	var inPtr reflect.Value
+	if inValue.CanAddr() {
+		inPtr = inValue.Addr()
+	} else {
+		inPtr = reflect.New(inValue.Type())
+		inPtr.Elem().Set(inValue)
+	}
+	defer startCopyMethod(inPtr)()
+	switch method.name {
+	case "DeepCopyInto":
+		method.fn.Call([]reflect.Value{inPtr, outValue.Addr()})
+	case "DeepCopy":
+		if out := method.fn.Call([]reflect.Value{inPtr})[0]; out.IsNil() {
+			outValue.Set(reflect.Zero(outValue.Type()))
+		} else {
+			outValue.Set(out.Elem())
+		}
+	case "Clone":
+		outValue.Set(method.fn.Call([]reflect.Value{inPtr})[0])
+	}
+}
@@ -0,0 +1,148 @@
+package deepcopy
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type ObjectMeta struct {
+	Name   string
+	labels map[string]string
+}
+
+func (in *ObjectMeta) DeepCopyInto(out *ObjectMeta) {
+	*out = *in
+	out.labels = make(map[string]string, len(in.labels))
+	for k, v := range in.labels {
+		out.labels[k] = v
+	}
+}
+
+type Ledger struct {
+	Name    string
+	entries []int64
+}
+
+func (l *Ledger) DeepCopy() *Ledger {
+	return &Ledger{Name: l.Name, entries: append([]int64(nil), l.entries...)}
+}
+
+type Version struct {
+	major, minor int
+}
+
+func (v Version) Clone() Version {
+	return v
+}
+
+type Revision struct {
+	Number int
+	note   string
+}
+
+// Clone does not return a Revision, so it is not used by DeepCopy.
+func (r Revision) Clone() *Revision {
+	return &r
+}
+
+type Tenant struct {
+	Name  string
+	Admin *ObjectMeta
+}
+
+// DeepCopy calls DeepCopy on its own receiver, which is then copied field by
+// field instead of with this method again.
+func (t *Tenant) DeepCopy() *Tenant {
+	var out Tenant
+	if err := DeepCopy(t, &out); err != nil {
+		panic(err)
+	}
+	return &out
+}
+
+// session has no exported fields, so it is only copied by its method.
+type session struct {
+	token string
+	scope []string
+}
+
+func (s *session) DeepCopyInto(out *session) {
+	out.token = s.token
+	out.scope = append([]string(nil), s.scope...)
+}
+
+type Holder struct {
+	V interface{}
+}
+
+type Deployment struct {
+	Meta     ObjectMeta
+	Ledgers  []*Ledger
+	Versions map[string]Version
+	Revision Revision
+	Tenants  []Tenant
+}
+
+func TestCopyMethods(t *testing.T) {
+	input := Deployment{
+		Meta:     ObjectMeta{Name: "api", labels: map[string]string{"tier": "backend"}},
+		Ledgers:  []*Ledger{{Name: "fees", entries: []int64{150, 200}}},
+		Versions: map[string]Version{"current": {major: 1, minor: 4}},
+		Revision: Revision{Number: 3, note: "hotfix"},
+		Tenants:  []Tenant{{Name: "acme", Admin: &ObjectMeta{Name: "root", labels: map[string]string{"role": "admin"}}}},
+	}
+	out := Deployment{}
+	err := DeepCopy(input, &out)
+	require.NoError(t, err)
+
+	assert.Equal(t, input.Meta, out.Meta)
+	out.Meta.labels["tier"] = "frontend"
+	assert.Equal(t, "backend", input.Meta.labels["tier"])
+
+	require.Len(t, out.Ledgers, 1)
+	assert.Equal(t, input.Ledgers[0], out.Ledgers[0])
+	assert.NotSame(t, input.Ledgers[0], out.Ledgers[0])
+	out.Ledgers[0].entries[0] = 0
+	assert.Equal(t, int64(150), input.Ledgers[0].entries[0])
+
+	assert.Equal(t, input.Versions, out.Versions)
+	assert.Equal(t, Revision{Number: 3}, out.Revision)
+
+	assert.Equal(t, input.Tenants, out.Tenants)
+	assert.NotSame(t, input.Tenants[0].Admin, out.Tenants[0].Admin)
+}
+
+func TestCopyMethodsTopLevel(t *testing.T) {
+	meta := ObjectMeta{Name: "api", labels: map[string]string{"tier": "backend"}}
+	cloned, err := Clone(meta)
+	require.NoError(t, err)
+	assert.Equal(t, meta, cloned)
+
+	var out ObjectMeta
+	require.NoError(t, DeepCopy(&meta, &out))
+	assert.Equal(t, meta, out)
+	out.labels["tier"] = "frontend"
+	assert.Equal(t, "backend", meta.labels["tier"])
+}
+
+func TestCopyMethodsOpaqueInterface(t *testing.T) {
+	input := Holder{V: &session{token: "abc", scope: []string{"read"}}}
+	var out Holder
+	require.NoError(t, DeepCopy(input, &out))
+	assert.Equal(t, input, out)
+	assert.NotSame(t, input.V, out.V)
+	out.V.(*session).scope[0] = "write"
+	assert.Equal(t, "read", input.V.(*session).scope[0])
+}
+
+func TestCopyMethodCallingDeepCopy(t *testing.T) {
+	input := &Tenant{Name: "acme", Admin: &ObjectMeta{Name: "root", labels: map[string]string{"role": "admin"}}}
+	out := input.DeepCopy()
+	assert.Equal(t, input, out)
+	assert.NotSame(t, input.Admin, out.Admin)
+
+	var copied Tenant
+	require.NoError(t, DeepCopy(*input, &copied))
+	assert.Equal(t, *input, copied)
+}
@@ -0,0 +1,131 @@
+package deepcopy
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy rewrites a field name or tag name before fields are
+// matched. Both sides of a comparison are rewritten, so strategies map
+// names in different conventions onto a common form.
+type NamingStrategy func(name string) string
+
+// WithNamingStrategy rewrites field names and tag names with the given
+// strategies, applied in order, before they are compared:
+//
+//	deepcopy.New(deepcopy.WithNamingStrategy(deepcopy.StripSuffix("Pb"), deepcopy.SnakeCase))
+//
+// matches UserID, UserIdPb, userId and user_id with each other.
+func WithNamingStrategy(strategies ...NamingStrategy) Option {
+	return func(c *Copier) {
+		c.naming = append(c.naming, strategies...)
+	}
+}
+
+// SnakeCase converts a name to snake_case, treating runs of capitals as a
+// single word: "UserID" and "HTTPServer" become "user_id" and
+// "http_server". Hyphens and spaces are treated like underscores.
+func SnakeCase(name string) string {
+	words := splitWords(name)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, "_")
+}
+
+// CamelCase converts a name to camelCase: "user_id" and "UserID" become
+// "userId".
+func CamelCase(name string) string {
+	words := splitWords(name)
+	for i, word := range words {
+		word = strings.ToLower(word)
+		if i > 0 {
+			word = upperFirst(word)
+		}
+		words[i] = word
+	}
+	return strings.Join(words, "")
+}
+
+// NormalizeAcronyms capitalizes only the first letter of each run of
+// capitals: "UserID" and "HTTPServer" become "UserId" and "HttpServer".
+func NormalizeAcronyms(name string) string {
+	runes := []rune(name)
+	out := make([]rune, len(runes))
+	for i, r := range runes {
+		out[i] = r
+		if i == 0 || !unicode.IsUpper(r) || !unicode.IsUpper(runes[i-1]) {
+			continue
+		}
+		if i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			// the first letter of the next word
+			continue
+		}
+		out[i] = unicode.ToLower(r)
+	}
+	return string(out)
+}
+
+// StripPrefix removes the first matching prefix, compared
+// case-insensitively, from names that are longer than it.
+func StripPrefix(prefixes ...string) NamingStrategy {
+	return func(name string) string {
+		for _, prefix := range prefixes {
+			if len(name) > len(prefix) && strings.EqualFold(name[:len(prefix)], prefix) {
+				return name[len(prefix):]
+			}
+		}
+		return name
+	}
+}
+
+// StripSuffix removes the first matching suffix, compared
+// case-insensitively, from names that are longer than it.
+func StripSuffix(suffixes ...string) NamingStrategy {
+	return func(name string) string {
+		for _, suffix := range suffixes {
+			if n := len(name) - len(suffix); n > 0 && strings.EqualFold(name[n:], suffix) {
+				return name[:n]
+			}
+		}
+		return name
+	}
+}
+
+// splitWords splits a name at underscores, hyphens, spaces and case
+// changes. A run of capitals is one word, except for its last letter when
+// that starts a lowercase word.
+func splitWords(name string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		if r == '_' || r == '-' || r == ' ' {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = nil
+			}
+			continue
+		}
+		if len(word) > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			if !unicode.IsUpper(prev) || i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
+
+func upperFirst(word string) string {
+	runes := []rune(word)
+	if len(runes) > 0 {
+		runes[0] = unicode.ToUpper(runes[0])
+	}
+	return string(runes)
+}
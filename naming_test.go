@@ -0,0 +1,106 @@
+package deepcopy
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNamingStrategies(t *testing.T) {
+	testCases := []struct {
+		name     string
+		strategy NamingStrategy
+		input    string
+		expected string
+	}{
+		{name: "snake case from camel case", strategy: SnakeCase, input: "userId", expected: "user_id"},
+		{name: "snake case from acronym", strategy: SnakeCase, input: "UserID", expected: "user_id"},
+		{name: "snake case with leading acronym", strategy: SnakeCase, input: "HTTPServer", expected: "http_server"},
+		{name: "snake case from kebab case", strategy: SnakeCase, input: "user-id", expected: "user_id"},
+		{name: "snake case keeps digits", strategy: SnakeCase, input: "Address2Line", expected: "address2_line"},
+		{name: "camel case from snake case", strategy: CamelCase, input: "user_id", expected: "userId"},
+		{name: "camel case from acronym", strategy: CamelCase, input: "UserID", expected: "userId"},
+		{name: "normalized acronym", strategy: NormalizeAcronyms, input: "UserID", expected: "UserId"},
+		{name: "normalized leading acronym", strategy: NormalizeAcronyms, input: "HTTPServerURL", expected: "HttpServerUrl"},
+		{name: "stripped prefix", strategy: StripPrefix("Pb", "m_"), input: "m_UserId", expected: "UserId"},
+		{name: "prefix is not the whole name", strategy: StripPrefix("Pb"), input: "pb", expected: "pb"},
+		{name: "stripped suffix", strategy: StripSuffix("Identifier", "ID"), input: "UserIdentifier", expected: "User"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.strategy(tc.input))
+		})
+	}
+}
+
+type SqlxUser struct {
+	User_id    uint64
+	First_name string
+	HTTPHost   string
+}
+
+type ProtoUser struct {
+	UserID    uint64
+	FirstName string
+	HttpHost  string
+}
+
+type LegacyUser struct {
+	UserIdentifier uint64
+	FirstNameStr   string
+}
+
+func TestWithNamingStrategy(t *testing.T) {
+	testCases := []struct {
+		name            string
+		copier          *Copier
+		input           interface{}
+		outputPtr       interface{}
+		expectedRespPtr interface{}
+	}{
+		{
+			name:      "no strategy",
+			copier:    New(),
+			input:     SqlxUser{User_id: 4, First_name: "Jane", HTTPHost: "example.com"},
+			outputPtr: &ProtoUser{},
+			expectedRespPtr: &ProtoUser{
+				HttpHost: "example.com",
+			},
+		},
+		{
+			name:            "snake case",
+			copier:          New(WithNamingStrategy(SnakeCase)),
+			input:           SqlxUser{User_id: 4, First_name: "Jane", HTTPHost: "example.com"},
+			outputPtr:       &ProtoUser{},
+			expectedRespPtr: &ProtoUser{UserID: 4, FirstName: "Jane", HttpHost: "example.com"},
+		},
+		{
+			name:            "camel case is case-sensitive when asked",
+			copier:          New(WithNamingStrategy(CamelCase), WithCaseSensitiveNames()),
+			input:           SqlxUser{User_id: 4, First_name: "Jane", HTTPHost: "example.com"},
+			outputPtr:       &ProtoUser{},
+			expectedRespPtr: &ProtoUser{UserID: 4, FirstName: "Jane", HttpHost: "example.com"},
+		},
+		{
+			name:            "acronyms are normalized",
+			copier:          New(WithNamingStrategy(NormalizeAcronyms), WithCaseSensitiveNames()),
+			input:           SqlxUser{HTTPHost: "example.com"},
+			outputPtr:       &ProtoUser{},
+			expectedRespPtr: &ProtoUser{HttpHost: "example.com"},
+		},
+		{
+			name:            "stripped suffixes",
+			copier:          New(WithNamingStrategy(StripSuffix("Identifier", "ID", "Str"))),
+			input:           ProtoUser{UserID: 4, FirstName: "Jane"},
+			outputPtr:       &LegacyUser{},
+			expectedRespPtr: &LegacyUser{UserIdentifier: 4, FirstNameStr: "Jane"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.copier.Copy(tc.input, tc.outputPtr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRespPtr, tc.outputPtr)
+		})
+	}
+}
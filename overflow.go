@@ -0,0 +1,179 @@
+package deepcopy
+
+import (
+	"math"
+	"reflect"
+	"time"
+)
+
+// OverflowPolicy controls numeric conversions whose value does not fit in
+// the destination type, either because it is out of the destination's range
+// or because precision would be lost.
+type OverflowPolicy uint8
+
+const (
+	// OverflowError fails with ErrOverflow when a value is out of range,
+	// when a float has a fractional part and is copied into an integer, or
+	// when an integer whose magnitude exceeds 2^53 (2^24 for float32) is
+	// copied into a float.
+	OverflowError OverflowPolicy = iota
+	// OverflowTruncate drops fractional parts, truncating toward zero, and
+	// rounds large integers copied into floats, but still fails with
+	// ErrOverflow when a value is out of range.
+	OverflowTruncate
+	// OverflowSaturate behaves like OverflowTruncate, but replaces values
+	// that are out of range with the destination type's minimum or maximum.
+	OverflowSaturate
+	// OverflowAllow converts numbers exactly like a Go conversion, so out of
+	// range integers wrap around and out of range floats give
+	// implementation-specific results. Strings parsed into numbers still fail
+	// with ErrOverflow when they are out of range.
+	OverflowAllow
+)
+
+// WithOverflowPolicy sets how numeric conversions that overflow or lose
+// precision are handled. NaN is never copied into an integer unless the
+// policy is OverflowAllow. Defaults to OverflowError.
+func WithOverflowPolicy(policy OverflowPolicy) Option {
+	return func(c *Copier) {
+		c.overflowPolicy = policy
+	}
+}
+
+// convertNumber copies the number inValue into the numeric outValue,
+// checking its range and precision according to the overflow policy.
+// Numbers copied into or from durations are counted in the duration unit.
+func (c *Copier) convertNumber(inValue, outValue reflect.Value) error {
+	switch {
+	case outValue.Type() == durationType && inValue.Type() != durationType:
+		return c.toDuration(inValue, outValue)
+	case inValue.Type() == durationType && outValue.Type() != durationType:
+		return c.fromDuration(time.Duration(inValue.Int()), outValue)
+	}
+	return c.checkedConvert(inValue, outValue)
+}
+
+// checkedConvert converts inValue like convertNumber, ignoring durations.
+func (c *Copier) checkedConvert(inValue, outValue reflect.Value) error {
+	if c.overflowPolicy != OverflowAllow {
+		if handled, err := c.checkNumber(inValue, outValue); handled || err != nil {
+			return err
+		}
+	}
+	outValue.Set(inValue.Convert(outValue.Type()))
+	return nil
+}
+
+// checkNumber returns ErrOverflow if inValue does not fit in outValue. It
+// reports whether it has already set outValue to a saturated value.
+func (c *Copier) checkNumber(inValue, outValue reflect.Value) (bool, error) {
+	bits := outValue.Type().Bits()
+	switch {
+	case isSigned(outValue.Kind()):
+		max := int64(math.MaxInt64 >> (64 - bits))
+		min := -max - 1
+		switch {
+		case isSigned(inValue.Kind()):
+			if v := inValue.Int(); v < min {
+				return c.saturate(func() { outValue.SetInt(min) })
+			} else if v > max {
+				return c.saturate(func() { outValue.SetInt(max) })
+			}
+		case isUnsigned(inValue.Kind()):
+			if inValue.Uint() > uint64(max) {
+				return c.saturate(func() { outValue.SetInt(max) })
+			}
+		case isFloat(inValue.Kind()):
+			f, err := c.truncate(inValue.Float())
+			if err != nil {
+				return false, err
+			}
+			if f < float64(min) {
+				return c.saturate(func() { outValue.SetInt(min) })
+			} else if f >= -float64(min) {
+				return c.saturate(func() { outValue.SetInt(max) })
+			}
+		}
+	case isUnsigned(outValue.Kind()):
+		max := uint64(math.MaxUint64 >> (64 - bits))
+		switch {
+		case isSigned(inValue.Kind()):
+			if v := inValue.Int(); v < 0 {
+				return c.saturate(func() { outValue.SetUint(0) })
+			} else if uint64(v) > max {
+				return c.saturate(func() { outValue.SetUint(max) })
+			}
+		case isUnsigned(inValue.Kind()):
+			if inValue.Uint() > max {
+				return c.saturate(func() { outValue.SetUint(max) })
+			}
+		case isFloat(inValue.Kind()):
+			f, err := c.truncate(inValue.Float())
+			if err != nil {
+				return false, err
+			}
+			if f < 0 {
+				return c.saturate(func() { outValue.SetUint(0) })
+			} else if f >= math.Ldexp(1, bits) {
+				return c.saturate(func() { outValue.SetUint(max) })
+			}
+		}
+	case isFloat(outValue.Kind()):
+		// integers beyond ±2^mantissa might not be represented exactly
+		safe := int64(1) << 53
+		if bits == 32 {
+			safe = 1 << 24
+		}
+		switch {
+		case isSigned(inValue.Kind()):
+			if v := inValue.Int(); (v < -safe || v > safe) && c.overflowPolicy == OverflowError {
+				return false, ErrOverflow
+			}
+		case isUnsigned(inValue.Kind()):
+			if inValue.Uint() > uint64(safe) && c.overflowPolicy == OverflowError {
+				return false, ErrOverflow
+			}
+		case isFloat(inValue.Kind()):
+			if f := inValue.Float(); bits == 32 && !math.IsInf(f, 0) && math.Abs(f) > math.MaxFloat32 {
+				return c.saturate(func() { outValue.SetFloat(math.Copysign(math.MaxFloat32, f)) })
+			}
+		}
+	}
+	return false, nil
+}
+
+// saturate calls set when out of range values are saturated, and returns
+// ErrOverflow otherwise.
+func (c *Copier) saturate(set func()) (bool, error) {
+	if c.overflowPolicy != OverflowSaturate {
+		return false, ErrOverflow
+	}
+	set()
+	return true, nil
+}
+
+// truncate drops the fractional part of a float copied into an integer,
+// unless the policy requires it to be exact.
+func (c *Copier) truncate(f float64) (float64, error) {
+	t := math.Trunc(f)
+	if math.IsNaN(f) || t != f && c.overflowPolicy == OverflowError {
+		return 0, ErrOverflow
+	}
+	return t, nil
+}
+
+func isNumber(kind reflect.Kind) bool {
+	return isSigned(kind) || isUnsigned(kind) || isFloat(kind)
+}
+
+func isSigned(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+func isUnsigned(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uintptr
+}
+
+func isFloat(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
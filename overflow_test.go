@@ -0,0 +1,82 @@
+package deepcopy
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math"
+	"reflect"
+	"testing"
+)
+
+type LocalVehicle struct {
+	Odometer int64
+	Fuel     float64
+}
+
+type PbVehicle struct {
+	Odometer int16
+	Fuel     float32
+}
+
+type Miles uint8
+
+func TestOverflowPolicy(t *testing.T) {
+	testCases := []struct {
+		name     string
+		policy   OverflowPolicy
+		input    interface{}
+		output   interface{}
+		expected interface{}
+		overflow bool
+	}{
+		{name: "in range", policy: OverflowError, input: int64(120), output: new(int8), expected: int8(120)},
+		{name: "widening", policy: OverflowError, input: int8(-5), output: new(int64), expected: int64(-5)},
+		{name: "int too large", policy: OverflowError, input: int64(300), output: new(int8), overflow: true},
+		{name: "negative into uint", policy: OverflowError, input: -1, output: new(uint), overflow: true},
+		{name: "uint too large", policy: OverflowError, input: uint64(math.MaxUint64), output: new(int64), overflow: true},
+		{name: "named type", policy: OverflowError, input: 256, output: new(Miles), overflow: true},
+		{name: "fractional float", policy: OverflowError, input: 3.9, output: new(int), overflow: true},
+		{name: "whole float", policy: OverflowError, input: 3.0, output: new(int), expected: 3},
+		{name: "NaN", policy: OverflowSaturate, input: math.NaN(), output: new(int), overflow: true},
+		{name: "float too large", policy: OverflowError, input: 1e300, output: new(float32), overflow: true},
+		{name: "infinity", policy: OverflowError, input: math.Inf(-1), output: new(float32), expected: float32(math.Inf(-1))},
+		{name: "float32 rounding", policy: OverflowError, input: 0.1, output: new(float32), expected: float32(0.1)},
+		{name: "inexact int", policy: OverflowError, input: int64(1<<53 + 1), output: new(float64), overflow: true},
+		{name: "exact int", policy: OverflowError, input: int64(1 << 53), output: new(float64), expected: float64(1 << 53)},
+		{name: "truncate fractional float", policy: OverflowTruncate, input: -3.9, output: new(int), expected: -3},
+		{name: "truncate inexact int", policy: OverflowTruncate, input: uint32(1<<24 + 1), output: new(float32), expected: float32(1 << 24)},
+		{name: "truncate int too large", policy: OverflowTruncate, input: int64(300), output: new(int8), overflow: true},
+		{name: "saturate max", policy: OverflowSaturate, input: int64(300), output: new(int8), expected: int8(math.MaxInt8)},
+		{name: "saturate min", policy: OverflowSaturate, input: -1, output: new(uint16), expected: uint16(0)},
+		{name: "saturate named type", policy: OverflowSaturate, input: 256, output: new(Miles), expected: Miles(math.MaxUint8)},
+		{name: "saturate float", policy: OverflowSaturate, input: -1e300, output: new(float32), expected: float32(-math.MaxFloat32)},
+		{name: "saturate float into int", policy: OverflowSaturate, input: 1e30, output: new(int64), expected: int64(math.MaxInt64)},
+		{name: "allow wraps", policy: OverflowAllow, input: int64(300), output: new(int8), expected: int8(44)},
+		{name: "allow fractional float", policy: OverflowAllow, input: 3.9, output: new(int), expected: 3},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := New(WithOverflowPolicy(tc.policy)).Copy(tc.input, tc.output)
+			if tc.overflow {
+				assert.ErrorIs(t, err, ErrOverflow)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, reflect.ValueOf(tc.output).Elem().Interface())
+		})
+	}
+}
+
+func TestOverflowError(t *testing.T) {
+	input := LocalVehicle{Odometer: 123456, Fuel: 12.5}
+	var output PbVehicle
+	err := DeepCopy(input, &output)
+
+	var convErr *ConversionError
+	require.True(t, errors.As(err, &convErr))
+	assert.ErrorIs(t, err, ErrOverflow)
+	assert.Equal(t, "Odometer", convErr.Path)
+	assert.Equal(t, int64(123456), convErr.Value)
+	assert.EqualError(t, err, "Odometer: unable to convert %!s(int64=123456) (type int64) to type int16: value out of range")
+}
@@ -0,0 +1,198 @@
+package deepcopy
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseFormat selects the string formats accepted when strings are copied
+// into numbers, booleans and durations, in addition to base-10 integers,
+// floats accepted by strconv.ParseFloat and "t", "true", "f" and "false".
+// Formats can be combined with |.
+type ParseFormat uint8
+
+const (
+	// ParseDefault accepts no additional formats.
+	ParseDefault ParseFormat = 0
+	// ParseTrimSpace ignores leading and trailing white space.
+	ParseTrimSpace ParseFormat = 1 << 0
+	// ParsePrefixes accepts integers with 0x, 0o and 0b base prefixes, and
+	// underscores between digits, as in Go integer literals.
+	ParsePrefixes ParseFormat = 1 << 1
+	// ParseThousands accepts commas between groups of three digits, such as
+	// "1,234,567.89".
+	ParseThousands ParseFormat = 1 << 2
+	// ParseBoolWords accepts "yes", "on" and "1" as true, and "no", "off"
+	// and "0" as false.
+	ParseBoolWords ParseFormat = 1 << 3
+	// ParseDurations parses strings copied into time.Duration and
+	// *durationpb.Duration values with time.ParseDuration, such as "1h30m",
+	// and formats durations copied into strings with time.Duration.String.
+	// Other strings are still parsed as a number of units (see
+	// WithDurationUnit).
+	ParseDurations ParseFormat = 1 << 4
+	// ParseAll accepts every format.
+	ParseAll = ParseTrimSpace | ParsePrefixes | ParseThousands | ParseBoolWords | ParseDurations
+)
+
+// WithParseFormats sets the additional string formats accepted when
+// copying strings into numbers, booleans and durations. Defaults to
+// ParseDefault.
+func WithParseFormats(formats ParseFormat) Option {
+	return func(c *Copier) {
+		c.parseFormats = formats
+	}
+}
+
+var thousandsPattern = regexp.MustCompile(`^[+-]?[0-9]{1,3}(,[0-9]{3})+(\.[0-9]*)?$`)
+
+// ParseInt parses s as a 64-bit signed integer in the formats f accepts.
+// The error is ErrOverflow if s is out of range, and ErrUnconvertible if it
+// is not an integer.
+func (f ParseFormat) ParseInt(s string) (int64, error) {
+	s, base := f.normalize(s, true)
+	v, err := strconv.ParseInt(s, base, 64)
+	return v, parseError(err)
+}
+
+// ParseUint is like ParseInt, but for unsigned integers.
+func (f ParseFormat) ParseUint(s string) (uint64, error) {
+	s, base := f.normalize(s, true)
+	v, err := strconv.ParseUint(s, base, 64)
+	return v, parseError(err)
+}
+
+// ParseFloat is like ParseInt, but for 64-bit floats.
+func (f ParseFormat) ParseFloat(s string) (float64, error) {
+	s, _ = f.normalize(s, false)
+	v, err := strconv.ParseFloat(s, 64)
+	return v, parseError(err)
+}
+
+// normalize removes the white space and separators f accepts from s, and
+// returns the base to parse it in.
+func (f ParseFormat) normalize(s string, integer bool) (string, int) {
+	if f&ParseTrimSpace != 0 {
+		s = strings.TrimSpace(s)
+	}
+	if f&ParseThousands != 0 && thousandsPattern.MatchString(s) {
+		s = strings.ReplaceAll(s, ",", "")
+	}
+	if f&ParsePrefixes == 0 || !integer {
+		return s, 10
+	}
+	digits := strings.TrimLeft(s, "+-")
+	if len(digits) > 1 && digits[0] == '0' && strings.ContainsAny(digits[1:2], "xXoObB") {
+		return s, 0
+	}
+	// without a prefix, base 0 would treat a leading zero as octal
+	if strings.Contains(s, "_") && underscoresOK(digits) {
+		s = strings.ReplaceAll(s, "_", "")
+	}
+	return s, 10
+}
+
+// underscoresOK reports whether every underscore in s is between digits.
+func underscoresOK(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '_' && (i == 0 || i == len(s)-1 || !isDigit(s[i-1]) || !isDigit(s[i+1])) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+func parseError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return ErrOverflow
+	}
+	return ErrUnconvertible
+}
+
+// parseBool parses s as a boolean in the formats the Copier accepts.
+func (c *Copier) parseBool(s string) (value bool, ok bool) {
+	switch s = strings.ToLower(s); {
+	case s == "t" || s == "true" || s == strings.ToLower(c.trueString):
+		return true, true
+	case s == "f" || s == "false" || s == strings.ToLower(c.falseString):
+		return false, true
+	}
+	if c.parseFormats&ParseBoolWords != 0 {
+		switch s {
+		case "yes", "on", "1":
+			return true, true
+		case "no", "off", "0":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// parseStringFlexibly parses the string inValue into outValue, and reports
+// whether outValue is a number, boolean or duration that strings are parsed
+// into.
+func (c *Copier) parseStringFlexibly(inValue, outValue reflect.Value) (bool, error) {
+	s := inValue.String()
+	if c.parseFormats&ParseTrimSpace != 0 {
+		s = strings.TrimSpace(s)
+	}
+	if outValue.Kind() == reflect.Bool {
+		v, ok := c.parseBool(s)
+		if !ok {
+			return true, ErrUnconvertible
+		}
+		outValue.SetBool(v)
+		return true, nil
+	}
+	if outValue.Type() == durationType && c.parseFormats&ParseDurations != 0 {
+		if d, err := time.ParseDuration(s); err == nil {
+			outValue.SetInt(int64(d))
+			return true, nil
+		}
+	}
+
+	var parsed interface{}
+	var err error
+	switch kind := outValue.Kind(); {
+	case isSigned(kind):
+		parsed, err = c.parseFormats.ParseInt(s)
+	case isUnsigned(kind):
+		parsed, err = c.parseFormats.ParseUint(s)
+	case isFloat(kind):
+		parsed, err = c.parseFormats.ParseFloat(s)
+	default:
+		return false, nil
+	}
+	if err != nil {
+		return true, err
+	}
+	parsedValue := reflect.ValueOf(parsed)
+	// parsed strings are range checked even when overflows are allowed
+	if c.overflowPolicy == OverflowAllow && overflows(parsedValue, outValue) {
+		return true, ErrOverflow
+	}
+	return true, c.convertNumber(parsedValue, outValue)
+}
+
+// overflows reports whether the parsed int64, uint64 or float64 inValue is
+// out of the range of outValue, which has the same kind of number.
+func overflows(inValue, outValue reflect.Value) bool {
+	switch inValue.Kind() {
+	case reflect.Int64:
+		return outValue.OverflowInt(inValue.Int())
+	case reflect.Uint64:
+		return outValue.OverflowUint(inValue.Uint())
+	}
+	return outValue.OverflowFloat(inValue.Float())
+}
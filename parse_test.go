@@ -0,0 +1,95 @@
+package deepcopy
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type Odometer uint32
+
+type LocalRide struct {
+	Distance string
+	Duration string
+	Paid     string
+}
+
+type PbRide struct {
+	Distance Odometer
+	Duration time.Duration
+	Paid     bool
+}
+
+func TestParseFormats(t *testing.T) {
+	testCases := []struct {
+		name        string
+		formats     ParseFormat
+		policy      OverflowPolicy
+		input       string
+		output      interface{}
+		expected    interface{}
+		expectedErr error
+	}{
+		{name: "int", input: "-42", output: new(int), expected: -42},
+		{name: "named int", input: "42", output: new(Odometer), expected: Odometer(42)},
+		{name: "int8", input: "-128", output: new(int8), expected: int8(-128)},
+		{name: "int8 out of range", input: "300", output: new(int8), expectedErr: ErrOverflow},
+		{name: "int64 out of range", input: "9223372036854775808", output: new(int64), expectedErr: ErrOverflow},
+		{name: "uint", input: "42", output: new(uint), expected: uint(42)},
+		{name: "negative uint", input: "-1", output: new(uint16), expectedErr: ErrUnconvertible},
+		{name: "float32", input: "2.5", output: new(float32), expected: float32(2.5)},
+		{name: "float32 out of range", input: "1e300", output: new(float32), expectedErr: ErrOverflow},
+		{name: "bool", input: "T", output: new(bool), expected: true},
+		{name: "hex without prefixes", input: "0x1F", output: new(int), expectedErr: ErrUnconvertible},
+		{name: "hex", formats: ParsePrefixes, input: "0x1F", output: new(int), expected: 31},
+		{name: "negative octal", formats: ParsePrefixes, input: "-0o17", output: new(int), expected: -15},
+		{name: "binary", formats: ParsePrefixes, input: "0b1010_1010", output: new(uint8), expected: uint8(170)},
+		{name: "underscores", formats: ParsePrefixes, input: "1_000_000", output: new(int), expected: 1000000},
+		{name: "leading zero", formats: ParsePrefixes, input: "010", output: new(int), expected: 10},
+		{name: "misplaced underscore", formats: ParsePrefixes, input: "1__000", output: new(int), expectedErr: ErrUnconvertible},
+		{name: "thousands", formats: ParseThousands, input: "1,234,567", output: new(int), expected: 1234567},
+		{name: "thousands float", formats: ParseThousands, input: "-1,234.5", output: new(float64), expected: -1234.5},
+		{name: "misplaced thousands", formats: ParseThousands, input: "12,34", output: new(int), expectedErr: ErrUnconvertible},
+		{name: "space without trimming", input: " 42 ", output: new(int), expectedErr: ErrUnconvertible},
+		{name: "space", formats: ParseTrimSpace, input: " 42\t", output: new(int), expected: 42},
+		{name: "space bool", formats: ParseTrimSpace, input: " true\n", output: new(bool), expected: true},
+		{name: "bool words without format", input: "yes", output: new(bool), expectedErr: ErrUnconvertible},
+		{name: "bool yes", formats: ParseBoolWords, input: "Yes", output: new(bool), expected: true},
+		{name: "bool off", formats: ParseBoolWords, input: "off", output: new(bool), expected: false},
+		{name: "bool zero", formats: ParseBoolWords, input: "0", output: new(bool), expected: false},
+		{name: "duration nanoseconds", input: "1500", output: new(time.Duration), expected: 1500 * time.Nanosecond},
+		{name: "duration without format", input: "1h30m", output: new(time.Duration), expectedErr: ErrUnconvertible},
+		{name: "duration", formats: ParseDurations, input: "1h30m", output: new(time.Duration), expected: 90 * time.Minute},
+		{name: "duration falls back to nanoseconds", formats: ParseDurations, input: "1500", output: new(time.Duration), expected: 1500 * time.Nanosecond},
+		{name: "all", formats: ParseAll, input: " 0x1_F ", output: new(int), expected: 31},
+		{name: "allowed overflow", policy: OverflowAllow, input: "300", output: new(int8), expectedErr: ErrOverflow},
+		{name: "allowed uint overflow", policy: OverflowAllow, input: "256", output: new(Miles), expectedErr: ErrOverflow},
+		{name: "allowed float overflow", policy: OverflowAllow, input: "1e300", output: new(float32), expectedErr: ErrOverflow},
+		{name: "allowed in range", policy: OverflowAllow, input: "-128", output: new(int8), expected: int8(-128)},
+		{name: "saturated overflow", policy: OverflowSaturate, input: "300", output: new(int8), expected: int8(127)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := New(WithParseFormats(tc.formats), WithOverflowPolicy(tc.policy)).Copy(tc.input, tc.output)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, reflect.ValueOf(tc.output).Elem().Interface())
+		})
+	}
+}
+
+func TestParseFormatsStruct(t *testing.T) {
+	input := LocalRide{Distance: "12,500", Duration: "2h", Paid: "yes"}
+	var output PbRide
+	err := New(WithParseFormats(ParseAll)).Copy(input, &output)
+	require.NoError(t, err)
+	assert.Equal(t, PbRide{Distance: 12500, Duration: 2 * time.Hour, Paid: true}, output)
+
+	err = DeepCopy(input, &output)
+	assert.EqualError(t, err, "Distance: unable to convert 12,500 (type string) to type deepcopy.Odometer")
+}
@@ -0,0 +1,343 @@
+package deepcopy
+
+import (
+	"reflect"
+	"strings"
+)
+
+// structPlan lists, in order, the field copies needed to copy one struct
+// type into another. Plans are compiled once per type pair and cached on
+// the Copier, so repeated copies skip field matching entirely.
+type structPlan struct {
+	fields []fieldPlan
+	// unmatchedIn and unmatchedOut name the copyable source and
+	// destination fields that take part in no field copy.
+	unmatchedIn  []string
+	unmatchedOut []string
+	// required lists the destination fields tagged required.
+	required []requiredField
+}
+
+// requiredField is a destination field that must not be zero after a copy.
+type requiredField struct {
+	index []int
+	name  string
+}
+
+// fieldPlan copies the source field at index path in into the destination
+// field at index path out. Paths longer than one step reach fields promoted
+// from embedded structs.
+type fieldPlan struct {
+	in       []int
+	out      []int
+	name     string
+	copyZero bool
+	// conv is the converter registered for the exact field types, if any.
+	conv converterFunc
+}
+
+// structPlan returns the cached plan for copying inType into outType,
+// compiling it on first use.
+func (c *Copier) structPlan(inType, outType reflect.Type) *structPlan {
+	pair := typePair{src: inType, dst: outType}
+	c.mu.RLock()
+	plan, ok := c.plans[pair]
+	c.mu.RUnlock()
+	if ok {
+		return plan
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if plan, ok := c.plans[pair]; ok {
+		return plan
+	}
+	plan = c.compileStructPlan(inType, outType)
+	if c.plans == nil {
+		c.plans = make(map[typePair]*structPlan)
+	}
+	c.plans[pair] = plan
+	return plan
+}
+
+// compileStructPlan matches every exported source field with the first
+// matching exported destination field. Fields of embedded structs are
+// promoted following Go's shadowing rules, unless the embedded struct
+// itself is matched. Fields whose tag name is a dotted path are matched
+// with the nested field it selects on the other side. c.mu must be held.
+func (c *Copier) compileStructPlan(inType, outType reflect.Type) *structPlan {
+	plan := &structPlan{}
+	inFields := c.copyableFields(inType, false)
+	outFields := c.copyableFields(outType, true)
+	var inMatched, outMatched [][]int
+	for _, inField := range inFields {
+		if promotedFrom(inField, inMatched) {
+			// the embedded struct is copied as a whole
+			continue
+		}
+		if tagPath := c.parseTag(inField).path; tagPath != "" {
+			if outField, _, ok := c.fieldByPath(outType, tagPath, true); ok {
+				plan.fields = append(plan.fields, c.fieldPlan(inField, outField, inField.Name))
+			}
+			continue
+		}
+		for _, outField := range outFields {
+			if promotedFrom(outField, outMatched) || c.parseTag(outField).path != "" || !c.fieldsMatch(inField, outField) {
+				continue
+			}
+			plan.fields = append(plan.fields, c.fieldPlan(inField, outField, inField.Name))
+			if inField.Anonymous {
+				inMatched = append(inMatched, inField.Index)
+			}
+			if outField.Anonymous {
+				outMatched = append(outMatched, outField.Index)
+			}
+			break
+		}
+	}
+	for _, outField := range outFields {
+		if tagPath := c.parseTag(outField).path; tagPath != "" && !promotedFrom(outField, outMatched) {
+			if inField, name, ok := c.fieldByPath(inType, tagPath, false); ok {
+				plan.fields = append(plan.fields, c.fieldPlan(inField, outField, name))
+			}
+		}
+	}
+
+	var inUsed, outUsed [][]int
+	for _, fp := range plan.fields {
+		inUsed = append(inUsed, fp.in)
+		outUsed = append(outUsed, fp.out)
+	}
+	plan.unmatchedIn = unmatchedFields(inFields, inUsed)
+	plan.unmatchedOut = unmatchedFields(outFields, outUsed)
+
+	for _, outField := range outFields {
+		if c.parseTag(outField).required {
+			plan.required = append(plan.required, requiredField{index: outField.Index, name: outField.Name})
+		}
+	}
+	return plan
+}
+
+// unmatchedFields returns the names of the fields that no used index
+// sequence selects, either directly, through a struct they are nested in,
+// or through a field nested in them. Embedded structs whose fields are
+// promoted are not reported themselves.
+func unmatchedFields(fields []reflect.StructField, used [][]int) []string {
+	var names []string
+	for _, field := range fields {
+		matched := false
+		for _, index := range used {
+			if isIndexPrefix(index, field.Index) || isIndexPrefix(field.Index, index) {
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		promoted := false
+		for _, other := range fields {
+			if len(other.Index) > len(field.Index) && isIndexPrefix(field.Index, other.Index) {
+				promoted = true
+				break
+			}
+		}
+		if !promoted {
+			names = append(names, field.Name)
+		}
+	}
+	return names
+}
+
+// isIndexPrefix reports whether index starts with prefix.
+func isIndexPrefix(prefix, index []int) bool {
+	return len(prefix) <= len(index) && reflect.DeepEqual(prefix, index[:len(prefix)])
+}
+
+// fieldPlan returns the plan copying inField into outField. name is the
+// path of inField used in errors.
+func (c *Copier) fieldPlan(inField, outField reflect.StructField, name string) fieldPlan {
+	fp := fieldPlan{
+		in:       inField.Index,
+		out:      outField.Index,
+		name:     name,
+		copyZero: c.copyZeroField(inField, outField),
+	}
+	// nillable source fields go through smartCopy, which
+	// dereferences them before consulting converters
+	if k := inField.Type.Kind(); k != reflect.Ptr && k != reflect.Interface {
+		fp.conv = c.converters[typePair{src: inField.Type, dst: outField.Type}]
+	}
+	return fp
+}
+
+// isFieldPath reports whether a tag name is a dotted path to a nested field,
+// such as "Address.City".
+func isFieldPath(name string) bool {
+	return strings.Contains(name, ".")
+}
+
+// fieldByPath looks up the nested field of t selected by a dotted path,
+// matching each element like a field name. The returned field's Index is
+// relative to t, and name is the path spelled with the fields' names.
+// Intermediate fields must be structs or pointers to structs. settable is
+// passed on to copyableFields.
+func (c *Copier) fieldByPath(t reflect.Type, path string, settable bool) (field reflect.StructField, name string, ok bool) {
+	var index []int
+	var names []string
+	for i, elem := range strings.Split(path, ".") {
+		if i > 0 {
+			if t = field.Type; t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			if t.Kind() != reflect.Struct {
+				return reflect.StructField{}, "", false
+			}
+		}
+		found := false
+		for _, f := range c.copyableFields(t, settable) {
+			if c.fieldNameMatches(f, elem) {
+				field, found = f, true
+				break
+			}
+		}
+		if !found {
+			return reflect.StructField{}, "", false
+		}
+		index = append(index, field.Index...)
+		names = append(names, field.Name)
+	}
+	field.Index = index
+	return field, strings.Join(names, "."), true
+}
+
+// copyableFields returns the exported fields of t, including those
+// promoted from embedded structs. Embedded structs with a tag name are
+// treated as regular fields. Fields tagged "-" are left out, and so are
+// readonly fields when settable is true, and writeonly fields otherwise;
+// fields promoted from them are left out as well. When settable is true,
+// fields promoted through unexported embedded pointers are also left out,
+// since they cannot be allocated.
+func (c *Copier) copyableFields(t reflect.Type, settable bool) []reflect.StructField {
+	var fields []reflect.StructField
+	var sealed [][]int
+	for _, field := range reflect.VisibleFields(t) {
+		if promotedFrom(field, sealed) {
+			continue
+		}
+		if tag := c.parseTag(field); tag.ignore || settable && tag.readOnly || !settable && tag.writeOnly {
+			sealed = append(sealed, field.Index)
+			continue
+		}
+		if field.Anonymous {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			switch {
+			case ft.Kind() != reflect.Struct || c.parseTag(field).name != "":
+				sealed = append(sealed, field.Index)
+			case settable && !field.IsExported() && field.Type.Kind() == reflect.Ptr:
+				sealed = append(sealed, field.Index)
+			}
+		}
+		if field.IsExported() {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// promotedFrom reports whether field is promoted from one of the embedded
+// struct fields at the given index paths.
+func promotedFrom(field reflect.StructField, embedded [][]int) bool {
+	for _, index := range embedded {
+		if len(field.Index) > len(index) && reflect.DeepEqual(field.Index[:len(index)], index) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldByIndex returns the field of v at index. A field promoted through a
+// nil embedded pointer reads as the zero value of its type.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	if len(index) == 1 {
+		return v.Field(index[0])
+	}
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Zero(v.Type().Elem().FieldByIndex(index[i:]).Type)
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// settableFieldByIndex returns the field of v at index, allocating nil
+// embedded pointers on the way.
+func settableFieldByIndex(v reflect.Value, index []int) reflect.Value {
+	if len(index) == 1 {
+		return v.Field(index[0])
+	}
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// FieldMapping describes a source struct field that is copied into a
+// destination struct field.
+type FieldMapping struct {
+	// Src and Dst are the index sequences of the fields in the source and
+	// destination struct types, as used by reflect.Type.FieldByIndex.
+	// They are longer than one for fields promoted from embedded structs.
+	Src []int
+	Dst []int
+	// Name is the path of the source field reported in errors, such as
+	// "Address.City" for a field selected by a dotted tag.
+	Name string
+	// CopyZero reports whether the field is copied even when it holds the
+	// zero value for its type.
+	CopyZero bool
+}
+
+// RequiredFields returns the index sequences of the fields of outType, a
+// struct type, that are tagged required.
+func (c *Copier) RequiredFields(outType reflect.Type) [][]int {
+	var required [][]int
+	for _, field := range c.copyableFields(outType, true) {
+		if c.parseTag(field).required {
+			required = append(required, field.Index)
+		}
+	}
+	return required
+}
+
+// FieldMappings returns, in copy order, the fields of inType that are
+// copied into fields of outType, both of which must be struct types. It
+// lets code generators such as deepcopy-gen match fields exactly like
+// Copy does.
+func (c *Copier) FieldMappings(inType, outType reflect.Type) []FieldMapping {
+	plan := c.structPlan(inType, outType)
+	mappings := make([]FieldMapping, len(plan.fields))
+	for i, fp := range plan.fields {
+		mappings[i] = FieldMapping{
+			Src:      append([]int(nil), fp.in...),
+			Dst:      append([]int(nil), fp.out...),
+			Name:     fp.name,
+			CopyZero: fp.copyZero,
+		}
+	}
+	return mappings
+}
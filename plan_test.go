@@ -0,0 +1,90 @@
+package deepcopy
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type PlanA struct {
+	Name   string
+	Amount Money
+	hidden string
+}
+
+type PlanB struct {
+	Name   string
+	Amount int64
+}
+
+func TestStructPlan(t *testing.T) {
+	copier := New()
+	inType := reflect.TypeOf(LocalFunky{})
+	outType := reflect.TypeOf(DcFunky{})
+
+	plan := copier.structPlan(inType, outType)
+	assert.Equal(t, []fieldPlan{
+		{in: []int{0}, out: []int{0}, name: "Hello"},
+		{in: []int{1}, out: []int{1}, name: "Sup"},
+	}, plan.fields)
+	assert.Same(t, plan, copier.structPlan(inType, outType))
+}
+
+func TestStructPlanConverters(t *testing.T) {
+	copier := New()
+	inType := reflect.TypeOf(PlanA{})
+	outType := reflect.TypeOf(PlanB{})
+
+	plan := copier.structPlan(inType, outType)
+	require.Len(t, plan.fields, 2)
+	assert.Nil(t, plan.fields[1].conv)
+
+	registerConverter(copier, moneyToCents)
+	plan = copier.structPlan(inType, outType)
+	require.Len(t, plan.fields, 2)
+	assert.NotNil(t, plan.fields[1].conv)
+
+	out := PlanB{}
+	err := copier.Copy(PlanA{Name: "fee", Amount: Money{cents: 150}}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, PlanB{Name: "fee", Amount: 150}, out)
+}
+
+func TestConcurrentCopies(t *testing.T) {
+	copier := New(WithConverter(moneyToCents))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			out := PlanB{}
+			err := copier.Copy(PlanA{Name: "fee", Amount: Money{cents: int64(i)}}, &out)
+			assert.NoError(t, err)
+			assert.Equal(t, PlanB{Name: "fee", Amount: int64(i)}, out)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func BenchmarkDeepCopyStructSlice(b *testing.B) {
+	input := make([]LocalInspection, 100)
+	for i := range input {
+		input[i] = LocalInspection{
+			UUID:          "hello there",
+			ID:            uint(i),
+			UserID:        uint64(i),
+			IsValid:       true,
+			ReservationID: uint(i),
+			Keys:          map[string]string{"hi": "you"},
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var out []PbInspection
+		if err := DeepCopy(input, &out); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package deepcopy
+
+import (
+	"reflect"
+	"strings"
+)
+
+// StrictMode selects which unmatched struct fields make a copy fail.
+// Modes can be combined with |.
+type StrictMode uint8
+
+const (
+	// StrictOff ignores unmatched fields.
+	StrictOff StrictMode = 0
+	// StrictSource reports source fields without a matching destination
+	// field.
+	StrictSource StrictMode = 1 << 0
+	// StrictDestination reports destination fields that no source field
+	// is copied into.
+	StrictDestination StrictMode = 1 << 1
+	// StrictAll reports both.
+	StrictAll = StrictSource | StrictDestination
+)
+
+// WithStrictMode makes Copy return an *UnmatchedFieldsError listing the
+// fields selected by mode that were left out of struct copies. Fields
+// tagged "-", readonly destination fields and writeonly source fields are
+// never reported. The copy itself still completes. Defaults to StrictOff.
+func WithStrictMode(mode StrictMode) Option {
+	return func(c *Copier) {
+		c.strictMode = mode
+	}
+}
+
+// UnmatchedFieldsError is returned by a Copier created with WithStrictMode
+// when struct fields were left out of the copy. Each type pair is reported
+// once, at the first path it was copied at.
+type UnmatchedFieldsError struct {
+	// Source and Destination hold the paths of unmatched fields, such as
+	// "Orders[0].Discount".
+	Source      []string
+	Destination []string
+}
+
+func (e *UnmatchedFieldsError) Error() string {
+	var msgs []string
+	if len(e.Source) > 0 {
+		msgs = append(msgs, "source fields "+strings.Join(e.Source, ", "))
+	}
+	if len(e.Destination) > 0 {
+		msgs = append(msgs, "destination fields "+strings.Join(e.Destination, ", "))
+	}
+	return "unmatched " + strings.Join(msgs, "; unmatched ")
+}
+
+func (e *UnmatchedFieldsError) Is(target error) bool {
+	return target == ErrUnmatchedField
+}
+
+// recordUnmatched adds the unmatched fields of plan, a plan for copying
+// inType into outType at path, to the strict mode report.
+func (s *copyState) recordUnmatched(path string, inType, outType reflect.Type, plan *structPlan) {
+	pair := typePair{src: inType, dst: outType}
+	if s.reported[pair] {
+		return
+	}
+	if s.reported == nil {
+		s.reported = make(map[typePair]bool)
+	}
+	s.reported[pair] = true
+
+	var source, destination []string
+	if s.strictMode&StrictSource != 0 {
+		for _, name := range plan.unmatchedIn {
+			source = append(source, fieldPath(path, name))
+		}
+	}
+	if s.strictMode&StrictDestination != 0 {
+		for _, name := range plan.unmatchedOut {
+			destination = append(destination, fieldPath(path, name))
+		}
+	}
+	if source == nil && destination == nil {
+		return
+	}
+	if s.unmatched == nil {
+		s.unmatched = &UnmatchedFieldsError{}
+	}
+	s.unmatched.Source = append(s.unmatched.Source, source...)
+	s.unmatched.Destination = append(s.unmatched.Destination, destination...)
+}
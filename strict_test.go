@@ -0,0 +1,105 @@
+package deepcopy
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type LocalShipment struct {
+	TrackingId string
+	Carrier    string
+	Notes      string `dc:"-"`
+	Stops      []LocalStop
+}
+
+type LocalStop struct {
+	City     string
+	Sequence int
+}
+
+type PbShipment struct {
+	TrackingNumber string
+	Carrier        string
+	CreatedBy      string `dc:",readonly"`
+	Stops          []PbStop
+}
+
+type PbStop struct {
+	City  string
+	Order int
+}
+
+func TestStrictMode(t *testing.T) {
+	input := LocalShipment{
+		TrackingId: "1Z999",
+		Carrier:    "UPS",
+		Stops:      []LocalStop{{City: "Denver", Sequence: 1}, {City: "Boulder", Sequence: 2}},
+	}
+
+	testCases := []struct {
+		name        string
+		mode        StrictMode
+		expectedErr *UnmatchedFieldsError
+	}{
+		{
+			name: "off",
+			mode: StrictOff,
+		},
+		{
+			name: "source fields",
+			mode: StrictSource,
+			expectedErr: &UnmatchedFieldsError{
+				Source: []string{"TrackingId", "Stops[0].Sequence"},
+			},
+		},
+		{
+			name: "destination fields",
+			mode: StrictDestination,
+			expectedErr: &UnmatchedFieldsError{
+				Destination: []string{"TrackingNumber", "Stops[0].Order"},
+			},
+		},
+		{
+			name: "all fields",
+			mode: StrictAll,
+			expectedErr: &UnmatchedFieldsError{
+				Source:      []string{"TrackingId", "Stops[0].Sequence"},
+				Destination: []string{"TrackingNumber", "Stops[0].Order"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := PbShipment{}
+			err := New(WithStrictMode(tc.mode)).Copy(input, &out)
+			assert.Equal(t, "UPS", out.Carrier)
+			assert.Equal(t, "Boulder", out.Stops[1].City)
+			if tc.expectedErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrUnmatchedField))
+			var unmatchedErr *UnmatchedFieldsError
+			require.True(t, errors.As(err, &unmatchedErr))
+			assert.Equal(t, tc.expectedErr, unmatchedErr)
+		})
+	}
+}
+
+func TestStrictModeError(t *testing.T) {
+	err := New(WithStrictMode(StrictAll)).Copy(LocalShipment{Carrier: "UPS"}, &PbShipment{})
+	require.Error(t, err)
+	assert.Equal(t, "unmatched source fields TrackingId; unmatched destination fields TrackingNumber", err.Error())
+
+	copier := New(WithStrictMode(StrictSource), WithCollectErrors(0))
+	err = copier.Copy(map[string]interface{}{"first": LocalShipment{Carrier: "UPS"}, "second": 2}, &map[string]PbShipment{})
+	require.Error(t, err)
+	var errs Errors
+	require.True(t, errors.As(err, &errs))
+	require.Len(t, errs, 2)
+	assert.True(t, errors.Is(errs[0], ErrUnconvertible))
+	assert.Equal(t, &UnmatchedFieldsError{Source: []string{"[first].TrackingId"}}, errs[1])
+}
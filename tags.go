@@ -0,0 +1,114 @@
+package deepcopy
+
+import (
+	"reflect"
+	"strings"
+)
+
+// fieldTag is a parsed struct tag of the form `dc:"name,option,..."`.
+type fieldTag struct {
+	name      string
+	copyZero  bool
+	omitEmpty bool
+	// path is set to name when it is a dotted path to a nested field.
+	path string
+	// ignore is set by the name "-": the field never matches.
+	ignore bool
+	// readOnly fields are never written, and writeOnly fields never read.
+	readOnly  bool
+	writeOnly bool
+	// required destination fields must not be zero after a copy.
+	required bool
+}
+
+func (c *Copier) parseTag(field reflect.StructField) fieldTag {
+	parts := strings.Split(field.Tag.Get(c.tagName), ",")
+	tag := fieldTag{
+		name: parts[0],
+	}
+	if isFieldPath(tag.name) {
+		tag.path = tag.name
+	}
+	if tag.name == "-" && len(parts) == 1 {
+		tag.name = ""
+		tag.ignore = true
+	}
+	for _, option := range parts[1:] {
+		switch strings.TrimSpace(option) {
+		case "copyzero":
+			tag.copyZero = true
+		case "omitempty":
+			tag.omitEmpty = true
+		case "readonly":
+			tag.readOnly = true
+		case "writeonly":
+			tag.writeOnly = true
+		case "required":
+			tag.required = true
+		}
+	}
+	for _, key := range c.fallbackTags {
+		if tag.name != "" || tag.ignore {
+			break
+		}
+		tag.name = fallbackTagName(field.Tag, key)
+	}
+	return tag
+}
+
+// fallbackTagName returns the field name given by the tag key, which is
+// the "name=" part of protobuf tags and the first part of any other tag.
+// The name "-" is ignored.
+func fallbackTagName(tag reflect.StructTag, key string) string {
+	parts := strings.Split(tag.Get(key), ",")
+	name := parts[0]
+	if key == "protobuf" {
+		name = ""
+		for _, part := range parts {
+			if strings.HasPrefix(part, "name=") {
+				name = strings.TrimPrefix(part, "name=")
+				break
+			}
+		}
+	}
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// WithFallbackTags matches fields using the given struct tags, consulted in
+// order, when a field has no "dc" tag name:
+//
+//	deepcopy.New(deepcopy.WithFallbackTags("protobuf", "json", "db"))
+//
+// Protobuf tags contribute their "name=" part, and other tags their first
+// comma-separated part. Options such as copyzero are only read from the
+// "dc" tag, and only "dc" tag names can be dotted paths.
+func WithFallbackTags(keys ...string) Option {
+	return func(c *Copier) {
+		c.fallbackTags = append(c.fallbackTags, keys...)
+	}
+}
+
+// copyZeroField reports whether a zero-valued inField is copied into
+// outField. The omitempty and copyzero tag options, on either field, take
+// precedence over the Copier's ZeroPolicy, and omitempty wins when both
+// are present.
+func (c *Copier) copyZeroField(inField, outField reflect.StructField) bool {
+	inTag := c.parseTag(inField)
+	outTag := c.parseTag(outField)
+	if inTag.omitEmpty || outTag.omitEmpty {
+		return false
+	}
+	if inTag.copyZero || outTag.copyZero {
+		return true
+	}
+	switch c.zeroPolicy {
+	case ZeroCopy:
+		return true
+	case ZeroPresence:
+		return outField.Type.Kind() == reflect.Ptr && inField.Type.Kind() != reflect.Ptr
+	}
+	return false
+}
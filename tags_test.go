@@ -0,0 +1,459 @@
+package deepcopy
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type LocalVehicleStatus struct {
+	IsActive bool
+	Miles    uint64
+	Note     *string
+}
+
+type PbVehicleStatus struct {
+	IsActive *bool
+	Miles    uint64
+	Note     *string
+}
+
+type TaggedVehicleStatus struct {
+	IsActive bool   `dc:",copyzero"`
+	Miles    uint64 `dc:"odometer,copyzero"`
+	Note     string `dc:",omitempty"`
+}
+
+type OdometerVehicleStatus struct {
+	IsActive bool
+	Odometer uint64
+	Note     string
+}
+
+func TestZeroPolicy(t *testing.T) {
+	note := "needs wash"
+	falseValue := false
+
+	testCases := []struct {
+		name            string
+		copier          *Copier
+		input           interface{}
+		outputPtr       interface{}
+		expectedRespPtr interface{}
+	}{
+		{
+			name:   "ZeroSkip leaves destination untouched",
+			copier: New(WithZeroPolicy(ZeroSkip)),
+			input:  LocalVehicleStatus{},
+			outputPtr: &OdometerVehicleStatus{
+				IsActive: true,
+				Note:     note,
+			},
+			expectedRespPtr: &OdometerVehicleStatus{
+				IsActive: true,
+				Note:     note,
+			},
+		},
+		{
+			name:   "ZeroCopy clears destination",
+			copier: New(WithZeroPolicy(ZeroCopy)),
+			input:  LocalVehicleStatus{},
+			outputPtr: &PbVehicleStatus{
+				IsActive: &[]bool{true}[0],
+				Miles:    10,
+				Note:     &note,
+			},
+			expectedRespPtr: &PbVehicleStatus{
+				IsActive: &falseValue,
+			},
+		},
+		{
+			name:   "ZeroPresence copies zero into pointer fields only",
+			copier: New(WithZeroPolicy(ZeroPresence)),
+			input:  LocalVehicleStatus{},
+			outputPtr: &PbVehicleStatus{
+				Miles: 10,
+				Note:  &note,
+			},
+			expectedRespPtr: &PbVehicleStatus{
+				IsActive: &falseValue,
+				Miles:    10,
+				Note:     &note,
+			},
+		},
+		{
+			name:   "copyzero tag on source field",
+			copier: New(),
+			input:  TaggedVehicleStatus{},
+			outputPtr: &OdometerVehicleStatus{
+				IsActive: true,
+				Odometer: 10,
+				Note:     note,
+			},
+			expectedRespPtr: &OdometerVehicleStatus{
+				Note: note,
+			},
+		},
+		{
+			name:   "copyzero tag on destination field",
+			copier: New(),
+			input:  OdometerVehicleStatus{},
+			outputPtr: &TaggedVehicleStatus{
+				IsActive: true,
+				Miles:    10,
+				Note:     note,
+			},
+			expectedRespPtr: &TaggedVehicleStatus{
+				Note: note,
+			},
+		},
+		{
+			name:   "omitempty tag overrides policy",
+			copier: New(WithZeroValues()),
+			input:  OdometerVehicleStatus{},
+			outputPtr: &TaggedVehicleStatus{
+				IsActive: true,
+				Miles:    10,
+				Note:     note,
+			},
+			expectedRespPtr: &TaggedVehicleStatus{
+				Note: note,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.copier.Copy(tc.input, tc.outputPtr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRespPtr, tc.outputPtr)
+		})
+	}
+}
+
+type Contact struct {
+	Email string
+	Phone string
+}
+
+type CustomerAddress struct {
+	Street string
+	City   string
+}
+
+type Customer struct {
+	Name    string
+	Address CustomerAddress
+	Contact *Contact
+}
+
+type CustomerDTO struct {
+	Name   string
+	Street string `dc:"Address.Street"`
+	City   string `dc:"address.city"`
+	Email  string `dc:"Contact.Email"`
+	Phone  int    `dc:"Contact.Phone"`
+}
+
+type CustomerRow struct {
+	FullName string `dc:"Name"`
+	City     string `dc:"Address.City"`
+	Email    string `dc:"Contact.Email"`
+	Missing  string `dc:"Contact.Fax"`
+}
+
+func TestFieldPathTags(t *testing.T) {
+	testCases := []struct {
+		name            string
+		input           interface{}
+		outputPtr       interface{}
+		expectedRespPtr interface{}
+		expectedErr     error
+	}{
+		{
+			name: "nested source to flat destination",
+			input: Customer{
+				Name:    "Jane",
+				Address: CustomerAddress{Street: "1 Main St", City: "Denver"},
+				Contact: &Contact{Email: "jane@example.com"},
+			},
+			outputPtr: &CustomerDTO{},
+			expectedRespPtr: &CustomerDTO{
+				Name:   "Jane",
+				Street: "1 Main St",
+				City:   "Denver",
+				Email:  "jane@example.com",
+			},
+		},
+		{
+			name: "nil intermediate pointer in source",
+			input: Customer{
+				Name: "Jane",
+			},
+			outputPtr: &CustomerDTO{Email: "old@example.com"},
+			expectedRespPtr: &CustomerDTO{
+				Name:  "Jane",
+				Email: "old@example.com",
+			},
+		},
+		{
+			name: "flat source to nested destination",
+			input: CustomerRow{
+				FullName: "Jane",
+				City:     "Denver",
+				Email:    "jane@example.com",
+				Missing:  "ignored",
+			},
+			outputPtr: &Customer{},
+			expectedRespPtr: &Customer{
+				Name:    "Jane",
+				Address: CustomerAddress{City: "Denver"},
+				Contact: &Contact{Email: "jane@example.com"},
+			},
+		},
+		{
+			name:            "intermediate pointer is not allocated for zero values",
+			input:           CustomerRow{FullName: "Jane"},
+			outputPtr:       &Customer{},
+			expectedRespPtr: &Customer{Name: "Jane"},
+		},
+		{
+			name: "errors report the nested path",
+			input: Customer{
+				Contact: &Contact{Phone: "555-0100"},
+			},
+			outputPtr:   &CustomerDTO{},
+			expectedErr: errors.New("Contact.Phone: unable to convert 555-0100 (type string) to type int"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := DeepCopy(tc.input, tc.outputPtr)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedRespPtr, tc.outputPtr)
+			}
+		})
+	}
+}
+
+type ProtoVehicle struct {
+	VehicleVin string `protobuf:"bytes,1,opt,name=vin,proto3" json:"vin,omitempty"`
+	Odometer   uint64 `protobuf:"varint,2,opt,name=miles,proto3" json:"odometer,omitempty"`
+	Plate      string `protobuf:"bytes,3,opt,name=plate,proto3" json:"-"`
+}
+
+type RowVehicle struct {
+	Vin      string `db:"vin"`
+	Mileage  uint64 `db:"miles" json:"odometer"`
+	PlateNum string `db:"plate" dc:"license"`
+}
+
+func TestWithFallbackTags(t *testing.T) {
+	input := ProtoVehicle{VehicleVin: "1HGCM82633A004352", Odometer: 1200, Plate: "ABC123"}
+
+	testCases := []struct {
+		name            string
+		copier          *Copier
+		expectedRespPtr *RowVehicle
+	}{
+		{
+			name:            "without fallback tags",
+			copier:          New(),
+			expectedRespPtr: &RowVehicle{},
+		},
+		{
+			name:   "protobuf names",
+			copier: New(WithFallbackTags("protobuf")),
+			expectedRespPtr: &RowVehicle{
+				Vin: "1HGCM82633A004352",
+			},
+		},
+		{
+			name:   "json before db",
+			copier: New(WithFallbackTags("json", "db")),
+			expectedRespPtr: &RowVehicle{
+				Vin:     "1HGCM82633A004352",
+				Mileage: 1200,
+			},
+		},
+		{
+			name:   "db before protobuf",
+			copier: New(WithFallbackTags("db", "protobuf")),
+			expectedRespPtr: &RowVehicle{
+				Vin:     "1HGCM82633A004352",
+				Mileage: 1200,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &RowVehicle{}
+			err := tc.copier.Copy(input, out)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRespPtr, out)
+		})
+	}
+}
+
+type AccountModel struct {
+	ID        uint64    `dc:",readonly"`
+	CreatedAt time.Time `dc:",readonly"`
+	Email     string
+	Password  string `dc:",writeonly"`
+	Notes     string `dc:"-"`
+}
+
+type AccountRequest struct {
+	ID        uint64
+	CreatedAt time.Time
+	Email     string
+	Password  string
+	Notes     string
+}
+
+type AccountEnvelope struct {
+	AccountModel `dc:",readonly"`
+	Email        string
+}
+
+func TestDirectionalTags(t *testing.T) {
+	createdAt := time.Date(2022, 8, 17, 0, 0, 0, 0, time.UTC)
+	model := AccountModel{ID: 7, CreatedAt: createdAt, Email: "jane@example.com", Password: "secret", Notes: "vip"}
+
+	testCases := []struct {
+		name            string
+		input           interface{}
+		outputPtr       interface{}
+		expectedRespPtr interface{}
+	}{
+		{
+			name: "readonly and ignored fields are not written",
+			input: AccountRequest{
+				ID:        99,
+				CreatedAt: createdAt.Add(time.Hour),
+				Email:     "new@example.com",
+				Password:  "hunter2",
+				Notes:     "overwritten",
+			},
+			outputPtr: &[]AccountModel{model}[0],
+			expectedRespPtr: &AccountModel{
+				ID:        7,
+				CreatedAt: createdAt,
+				Email:     "new@example.com",
+				Password:  "hunter2",
+				Notes:     "vip",
+			},
+		},
+		{
+			name:      "writeonly and ignored fields are not read",
+			input:     model,
+			outputPtr: &AccountRequest{},
+			expectedRespPtr: &AccountRequest{
+				ID:        7,
+				CreatedAt: createdAt,
+				Email:     "jane@example.com",
+			},
+		},
+		{
+			name:      "readonly embedded struct",
+			input:     AccountRequest{ID: 99, Email: "new@example.com"},
+			outputPtr: &AccountEnvelope{AccountModel: model},
+			expectedRespPtr: &AccountEnvelope{
+				AccountModel: model,
+				Email:        "new@example.com",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := DeepCopy(tc.input, tc.outputPtr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRespPtr, tc.outputPtr)
+		})
+	}
+}
+
+type CreateVehicleRequest struct {
+	Vin   string
+	Miles uint64
+	Owner *VehicleOwner
+}
+
+type VehicleOwner struct {
+	Name string
+}
+
+type VehicleRecord struct {
+	Vin       string `dc:",required"`
+	Miles     uint64 `dc:",required"`
+	FleetID   uint64 `dc:",required"`
+	OwnerName string `dc:"Owner.Name,required"`
+}
+
+func TestRequiredTag(t *testing.T) {
+	testCases := []struct {
+		name        string
+		copier      *Copier
+		input       interface{}
+		outputPtr   interface{}
+		expectedErr error
+	}{
+		{
+			name:   "all required fields populated",
+			copier: New(),
+			input: CreateVehicleRequest{
+				Vin:   "1HGCM82633A004352",
+				Miles: 10,
+				Owner: &VehicleOwner{Name: "Jane"},
+			},
+			outputPtr: &VehicleRecord{FleetID: 3},
+		},
+		{
+			name:   "zero source field",
+			copier: New(),
+			input: CreateVehicleRequest{
+				Vin:   "1HGCM82633A004352",
+				Owner: &VehicleOwner{Name: "Jane"},
+			},
+			outputPtr:   &VehicleRecord{FleetID: 3},
+			expectedErr: errors.New("Miles: required field of type uint64 is zero"),
+		},
+		{
+			name:   "unmatched field",
+			copier: New(),
+			input: CreateVehicleRequest{
+				Vin:   "1HGCM82633A004352",
+				Miles: 10,
+				Owner: &VehicleOwner{Name: "Jane"},
+			},
+			outputPtr:   &VehicleRecord{},
+			expectedErr: errors.New("FleetID: required field of type uint64 is zero"),
+		},
+		{
+			name:        "every missing field is collected",
+			copier:      New(WithCollectErrors(0)),
+			input:       []CreateVehicleRequest{{Miles: 10}},
+			outputPtr:   &[]VehicleRecord{},
+			expectedErr: errors.New("[0].Vin: required field of type string is zero\n[0].FleetID: required field of type uint64 is zero\n[0].OwnerName: required field of type string is zero"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.copier.Copy(tc.input, tc.outputPtr)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedErr.Error(), err.Error())
+				assert.True(t, errors.Is(err, ErrRequired))
+				var reqErr *RequiredFieldError
+				assert.True(t, errors.As(err, &reqErr))
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}